@@ -0,0 +1,263 @@
+package kurrentdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sync"
+)
+
+// AggregateRepository loads and saves event-sourced aggregates whose state is of type T. The state of an aggregate is
+// rebuilt by folding the events of its stream through the apply functions registered with RegisterApply, events being
+// converted from and to Go values by the configuration's Serializer. An AggregateRepository is safe for concurrent
+// use, the aggregates it returns are not.
+type AggregateRepository[T any] struct {
+	client       *Client
+	streamPrefix string
+	newState     func() T
+	snapshots    *SnapshotOptions
+	mu           sync.RWMutex
+	appliers     map[reflect.Type]func(state *T, event any)
+}
+
+// NewAggregateRepository creates a repository storing every aggregate in the stream named after its id, prefixed by
+// streamPrefix, such as "order-". newState returns the state of an aggregate before its first event, the zero value
+// of T if nil.
+func NewAggregateRepository[T any](client *Client, streamPrefix string, newState func() T) *AggregateRepository[T] {
+	if newState == nil {
+		newState = func() T {
+			var state T
+			return state
+		}
+	}
+
+	return &AggregateRepository[T]{
+		client:       client,
+		streamPrefix: streamPrefix,
+		newState:     newState,
+		appliers:     make(map[reflect.Type]func(state *T, event any)),
+	}
+}
+
+// RegisterApply registers how an event of type E changes the state of the aggregates of a repository. E must be
+// registered in the configuration's Serializer, by the same type. Registering a type twice fails.
+func RegisterApply[T, E any](repository *AggregateRepository[T], apply func(state *T, event E)) error {
+	eventType := reflect.TypeFor[E]()
+
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	if _, ok := repository.appliers[eventType]; ok {
+		return fmt.Errorf("an apply function is already registered for type %v", eventType)
+	}
+
+	repository.appliers[eventType] = func(state *T, event any) {
+		apply(state, event.(E))
+	}
+
+	return nil
+}
+
+// StreamID returns the name of the stream of the aggregate with the given id.
+func (repository *AggregateRepository[T]) StreamID(id string) string {
+	return repository.streamPrefix + id
+}
+
+// New returns an aggregate whose stream doesn't exist yet. Saving it fails if the stream was created in the meantime.
+func (repository *AggregateRepository[T]) New(id string) *Aggregate[T] {
+	return &Aggregate[T]{
+		id:         id,
+		state:      repository.newState(),
+		revision:   NoStream{},
+		repository: repository,
+	}
+}
+
+// Load reads the stream of an aggregate and applies its events to a new state. With snapshots enabled, it starts from
+// the latest snapshot and only applies the events appended after it. It fails with ErrorCodeResourceNotFound if the
+// stream doesn't exist, with ErrorCodeUnknownEventType if an event's type isn't registered in the Serializer, and if
+// no apply function is registered for an event.
+func (repository *AggregateRepository[T]) Load(ctx context.Context, id string) (*Aggregate[T], error) {
+	aggregate := repository.New(id)
+
+	var from StreamPosition = Start{}
+	if repository.snapshots != nil && repository.loadSnapshot(ctx, aggregate) {
+		from = Revision(aggregate.revision.(StreamRevision).Value + 1)
+	}
+
+	stream, err := repository.client.ReadStream(ctx, repository.StreamID(id), ReadStreamOptions{
+		Direction: Forwards,
+		From:      from,
+	}, math.MaxUint64)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer stream.Close()
+
+	serializer := repository.client.serializer()
+	for {
+		event, err := stream.Recv()
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := serializer.Decode(event)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := aggregate.apply(value); err != nil {
+			return nil, err
+		}
+
+		aggregate.revision = event.OriginalStreamRevision()
+	}
+
+	if _, isNew := aggregate.revision.(NoStream); isNew {
+		return nil, &Error{code: ErrorCodeResourceNotFound, err: fmt.Errorf("stream '%s' has no events", repository.StreamID(id))}
+	}
+
+	return aggregate, nil
+}
+
+// Save appends the events recorded on an aggregate since it was loaded or last saved, expecting its stream to still
+// be at the revision the aggregate was at. If the stream changed in the meantime, it fails with an Error of code
+// ErrorCodeWrongExpectedVersion wrapping an AggregateConflictError, and the aggregate is left as is: reload it and
+// record the events again. Saving an aggregate without recorded events does nothing.
+//
+// With snapshots enabled, a snapshot is taken when the stream reaches a multiple of SnapshotOptions.Every events.
+// Failing to take it is logged and doesn't fail Save, as the events are saved.
+func (repository *AggregateRepository[T]) Save(ctx context.Context, aggregate *Aggregate[T]) error {
+	if len(aggregate.pending) == 0 {
+		return nil
+	}
+
+	streamID := repository.StreamID(aggregate.id)
+	result, err := repository.client.AppendEvents(ctx, streamID, aggregate.revision, aggregate.pending...)
+	if err != nil {
+		var esErr *Error
+		if errors.As(err, &esErr) && (esErr.IsErrorCode(ErrorCodeWrongExpectedVersion) || esErr.IsErrorCode(ErrorCodeStreamRevisionConflict)) {
+			return &Error{code: ErrorCodeWrongExpectedVersion, err: &AggregateConflictError{
+				ID:               aggregate.id,
+				Stream:           streamID,
+				ExpectedRevision: aggregate.revision,
+				err:              err,
+			}}
+		}
+
+		return err
+	}
+
+	previous := aggregate.revision
+	aggregate.revision = Revision(result.NextExpectedVersion)
+	aggregate.pending = nil
+
+	if repository.snapshotDue(previous, aggregate.revision) {
+		if err := repository.Snapshot(ctx, aggregate); err != nil {
+			repository.client.grpcClient.logger.withError(err).warn("error when taking a snapshot, the events are saved", "stream", streamID)
+		}
+	}
+
+	return nil
+}
+
+// Aggregate is the state of an event-sourced domain object, along with the revision of its stream and the events
+// recorded since it was loaded.
+type Aggregate[T any] struct {
+	id         string
+	state      T
+	revision   StreamState
+	pending    []any
+	repository *AggregateRepository[T]
+}
+
+// ID returns the id of the aggregate.
+func (aggregate *Aggregate[T]) ID() string {
+	return aggregate.id
+}
+
+// State returns the state of the aggregate, including the events recorded but not saved yet.
+func (aggregate *Aggregate[T]) State() *T {
+	return &aggregate.state
+}
+
+// Revision returns the revision of the last event of the stream the aggregate was loaded from or saved to, NoStream
+// for a new aggregate. Save expects the stream to still be at that revision.
+func (aggregate *Aggregate[T]) Revision() StreamState {
+	return aggregate.revision
+}
+
+// Pending returns the events recorded since the aggregate was loaded or last saved.
+func (aggregate *Aggregate[T]) Pending() []any {
+	return aggregate.pending
+}
+
+// Record applies events to the state of the aggregate and queues them for the next Save. It fails without applying
+// anything if no apply function is registered for an event.
+func (aggregate *Aggregate[T]) Record(events ...any) error {
+	for _, event := range events {
+		if _, err := aggregate.repository.applier(event); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range events {
+		if err := aggregate.apply(event); err != nil {
+			return err
+		}
+
+		aggregate.pending = append(aggregate.pending, event)
+	}
+
+	return nil
+}
+
+func (aggregate *Aggregate[T]) apply(event any) error {
+	apply, err := aggregate.repository.applier(event)
+	if err != nil {
+		return err
+	}
+
+	apply(&aggregate.state, event)
+	return nil
+}
+
+func (repository *AggregateRepository[T]) applier(event any) (func(state *T, event any), error) {
+	repository.mu.RLock()
+	defer repository.mu.RUnlock()
+
+	apply, ok := repository.appliers[reflect.TypeOf(event)]
+	if !ok {
+		return nil, fmt.Errorf("no apply function is registered for type %T", event)
+	}
+
+	return apply, nil
+}
+
+// AggregateConflictError is wrapped in the error AggregateRepository.Save fails with when the stream of an aggregate
+// changed since the aggregate was loaded.
+type AggregateConflictError struct {
+	ID               string
+	Stream           string
+	ExpectedRevision StreamState
+	err              error
+}
+
+func (e *AggregateConflictError) Error() string {
+	return fmt.Sprintf("aggregate conflict: id=%s stream=%s expected_revision=%v: %v", e.ID, e.Stream, e.ExpectedRevision, e.err)
+}
+
+// Unwrap returns the error the append failed with.
+func (e *AggregateConflictError) Unwrap() error {
+	return e.err
+}
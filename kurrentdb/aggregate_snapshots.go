@@ -0,0 +1,204 @@
+package kurrentdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	snapshotEventType           = "Snapshot"
+	defaultSnapshotStreamPrefix = "snapshot-"
+)
+
+// SnapshotOptions configures the snapshots of an AggregateRepository. Snapshots are stored as events of a companion
+// stream per aggregate, named by AggregateRepository.SnapshotStreamID.
+type SnapshotOptions struct {
+	// Takes a snapshot every time Save makes the stream of an aggregate reach a multiple of Every events. Zero leaves
+	// snapshots to AggregateRepository.Snapshot calls.
+	Every uint64
+	// Number of snapshots kept in a snapshot stream, set as its $maxCount before appending a snapshot when it differs.
+	// Only the latest snapshot is read. Defaults to 1.
+	MaxCount uint64
+	// Encodes the state of aggregates. It is given a pointer to the state. Defaults to JsonCodec.
+	Codec Codec
+	// Prefix added to the stream of an aggregate to name its snapshot stream. It keeps snapshot streams out of the
+	// aggregates' category, and out of subscriptions filtering on their prefix. Defaults to "snapshot-".
+	StreamPrefix string
+}
+
+type snapshotMetadata struct {
+	Revision uint64 `json:"revision"`
+}
+
+// EnableSnapshots makes the repository take snapshots of aggregates and load them from their latest snapshot. Call it
+// before using the repository. A snapshot that can't be read or decoded, for instance because the state type changed,
+// is logged and ignored, and the aggregate is loaded from the start of its stream.
+func (repository *AggregateRepository[T]) EnableSnapshots(options SnapshotOptions) {
+	if options.MaxCount == 0 {
+		options.MaxCount = 1
+	}
+
+	if options.Codec == nil {
+		options.Codec = JsonCodec{}
+	}
+
+	if options.StreamPrefix == "" {
+		options.StreamPrefix = defaultSnapshotStreamPrefix
+	}
+
+	repository.snapshots = &options
+}
+
+// SnapshotStreamID returns the name of the stream holding the snapshots of the aggregate with the given id, such as
+// "snapshot-order-1".
+func (repository *AggregateRepository[T]) SnapshotStreamID(id string) string {
+	prefix := defaultSnapshotStreamPrefix
+	if repository.snapshots != nil {
+		prefix = repository.snapshots.StreamPrefix
+	}
+
+	return prefix + repository.StreamID(id)
+}
+
+// Snapshot stores the state of an aggregate in its snapshot stream. The aggregate must have been loaded or saved, with
+// no events recorded since.
+func (repository *AggregateRepository[T]) Snapshot(ctx context.Context, aggregate *Aggregate[T]) error {
+	if repository.snapshots == nil {
+		return fmt.Errorf("snapshots are not enabled")
+	}
+
+	if len(aggregate.pending) > 0 {
+		return fmt.Errorf("aggregate '%s' has unsaved events", aggregate.id)
+	}
+
+	revision, ok := aggregate.revision.(StreamRevision)
+	if !ok {
+		return fmt.Errorf("aggregate '%s' has no events", aggregate.id)
+	}
+
+	data, err := repository.snapshots.Codec.Marshal(&aggregate.state)
+	if err != nil {
+		return fmt.Errorf("error when serializing snapshot: %w", err)
+	}
+
+	metadata, err := json.Marshal(snapshotMetadata{Revision: revision.Value})
+	if err != nil {
+		return fmt.Errorf("error when serializing snapshot metadata: %w", err)
+	}
+
+	// The limit is checked on every snapshot so that a failed update, or a changed MaxCount, is caught up with later.
+	streamID := repository.SnapshotStreamID(aggregate.id)
+	if err := repository.limitSnapshots(ctx, streamID); err != nil {
+		return err
+	}
+
+	_, err = repository.client.AppendToStream(ctx, streamID, AppendToStreamOptions{StreamState: Any{}}, EventData{
+		EventType:   snapshotEventType,
+		ContentType: repository.snapshots.Codec.ContentType(),
+		Data:        data,
+		Metadata:    metadata,
+	})
+
+	return err
+}
+
+// limitSnapshots sets the $maxCount of a snapshot stream if it differs from MaxCount, keeping the rest of its
+// metadata.
+func (repository *AggregateRepository[T]) limitSnapshots(ctx context.Context, streamID string) error {
+	metadata, err := repository.client.GetStreamMetadata(ctx, streamID, ReadStreamOptions{
+		Direction: Backwards,
+		From:      End{},
+	})
+
+	var esErr *Error
+	if errors.As(err, &esErr) && esErr.IsErrorCode(ErrorCodeResourceNotFound) {
+		metadata, err = &StreamMetadata{}, nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error when reading snapshot stream metadata: %w", err)
+	}
+
+	if maxCount := metadata.MaxCount(); maxCount != nil && *maxCount == repository.snapshots.MaxCount {
+		return nil
+	}
+
+	metadata.SetMaxCount(repository.snapshots.MaxCount)
+
+	if _, err := repository.client.SetStreamMetadata(ctx, streamID, AppendToStreamOptions{StreamState: Any{}}, *metadata); err != nil {
+		return fmt.Errorf("error when setting snapshot stream metadata: %w", err)
+	}
+
+	return nil
+}
+
+// loadSnapshot applies the latest snapshot of an aggregate, if any, and tells if there was one.
+func (repository *AggregateRepository[T]) loadSnapshot(ctx context.Context, aggregate *Aggregate[T]) bool {
+	streamID := repository.SnapshotStreamID(aggregate.id)
+	logger := repository.client.grpcClient.logger
+
+	stream, err := repository.client.ReadStream(ctx, streamID, ReadStreamOptions{
+		Direction: Backwards,
+		From:      End{},
+	}, 1)
+
+	if err != nil {
+		logger.withError(err).warn("error when reading the latest snapshot, loading from the start", "stream", streamID)
+		return false
+	}
+
+	defer stream.Close()
+	event, err := stream.Recv()
+
+	if errors.Is(err, io.EOF) {
+		return false
+	}
+
+	if err != nil {
+		var esErr *Error
+		if !errors.As(err, &esErr) || !esErr.IsErrorCode(ErrorCodeResourceNotFound) {
+			logger.withError(err).warn("error when reading the latest snapshot, loading from the start", "stream", streamID)
+		}
+
+		return false
+	}
+
+	var metadata snapshotMetadata
+	if err := json.Unmarshal(event.OriginalEvent().UserMetadata, &metadata); err != nil {
+		logger.withError(err).warn("error when decoding the latest snapshot, loading from the start", "stream", streamID)
+		return false
+	}
+
+	state := repository.newState()
+	if err := repository.snapshots.Codec.Unmarshal(event.OriginalEvent().Data, &state); err != nil {
+		logger.withError(err).warn("error when decoding the latest snapshot, loading from the start", "stream", streamID)
+		return false
+	}
+
+	aggregate.state = state
+	aggregate.revision = Revision(metadata.Revision)
+
+	return true
+}
+
+// snapshotDue tells if a Save moving a stream from one revision to another made it reach a multiple of
+// SnapshotOptions.Every events.
+func (repository *AggregateRepository[T]) snapshotDue(from, to StreamState) bool {
+	if repository.snapshots == nil || repository.snapshots.Every == 0 {
+		return false
+	}
+
+	return eventCount(to)/repository.snapshots.Every > eventCount(from)/repository.snapshots.Every
+}
+
+// eventCount returns the number of events in a stream at a given revision.
+func eventCount(state StreamState) uint64 {
+	if revision, ok := state.(StreamRevision); ok {
+		return revision.Value + 1
+	}
+
+	return 0
+}
@@ -0,0 +1,68 @@
+package kurrentdb
+
+import (
+	"time"
+)
+
+// BatchAppenderOptions options of the batch appender.
+type BatchAppenderOptions struct {
+	// Asks for authenticated requests.
+	Authenticated *Credentials
+	// Requires the appends to be performed by the leader of the cluster.
+	RequiresLeader bool
+	// Default length of time given to each append. Defaults to the configuration's DefaultDeadline, or 10 seconds.
+	Deadline *time.Duration
+	// Maximum number of events sent in a single message, larger appends are split in several messages. Defaults to
+	// 1000.
+	MaxBatchSize int
+}
+
+func (o *BatchAppenderOptions) kind() operationKind {
+	return streamingOperation
+}
+
+func (o *BatchAppenderOptions) credentials() *Credentials {
+	return o.Authenticated
+}
+
+// deadline returns nil, as the deadline applies to each append, not to the underlying stream.
+func (o *BatchAppenderOptions) deadline() *time.Duration {
+	return nil
+}
+
+func (o *BatchAppenderOptions) requiresLeader() bool {
+	return o.RequiresLeader
+}
+
+func (o *BatchAppenderOptions) setDefaults(conf *Configuration) {
+	if o.Deadline == nil {
+		deadline := 10 * time.Second
+		if conf.DefaultDeadline != nil {
+			deadline = *conf.DefaultDeadline
+		}
+
+		o.Deadline = &deadline
+	}
+
+	if o.MaxBatchSize <= 0 {
+		o.MaxBatchSize = 1000
+	}
+}
+
+// BatchAppendOptions options of an append made through a batch appender.
+type BatchAppendOptions struct {
+	// Asks the server to check that the stream receiving the event is at the expected state.
+	StreamState StreamState
+	// A length of time given to the append. Defaults to the deadline of the batch appender.
+	Deadline *time.Duration
+}
+
+func (o *BatchAppendOptions) setDefaults(appender *BatchAppenderOptions) {
+	if o.StreamState == nil {
+		o.StreamState = Any{}
+	}
+
+	if o.Deadline == nil {
+		o.Deadline = appender.Deadline
+	}
+}
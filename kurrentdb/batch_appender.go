@@ -0,0 +1,365 @@
+package kurrentdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	grpcStatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/shared"
+	api "github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/streams"
+)
+
+// BatchAppender multiplexes concurrent appends over a single long-lived BatchAppend stream. Each append is identified
+// by a correlation id and completes independently of the others, which removes the cost of opening a gRPC call per
+// write. A BatchAppender is safe for concurrent use.
+type BatchAppender struct {
+	client   *Client
+	options  BatchAppenderOptions
+	handle   *connectionHandle
+	stream   api.Streams_BatchAppendClient
+	cancel   context.CancelFunc
+	trailers metadata.MD
+	sendMu   sync.Mutex
+	mu       sync.Mutex
+	pending  map[uuid.UUID]*AppendFuture
+	err      error
+	done     chan struct{}
+}
+
+// AppendFuture is the pending result of an append made through a BatchAppender.
+type AppendFuture struct {
+	once    sync.Once
+	done    chan struct{}
+	result  *WriteResult
+	err     error
+	cleanup func()
+}
+
+// Done returns a channel closed once the append completed.
+func (future *AppendFuture) Done() <-chan struct{} {
+	return future.done
+}
+
+// Result waits for the append to complete and returns its outcome.
+func (future *AppendFuture) Result() (*WriteResult, error) {
+	<-future.done
+	return future.result, future.err
+}
+
+func (future *AppendFuture) complete(result *WriteResult, err error) {
+	future.once.Do(func() {
+		if future.cleanup != nil {
+			future.cleanup()
+		}
+
+		future.result = result
+		future.err = err
+		close(future.done)
+	})
+}
+
+// NewBatchAppender opens a BatchAppend stream on the current node. The stream lives until Close is called, ctx is
+// cancelled or the connection fails, in which case pending and subsequent appends fail with the stream's error.
+func (client *Client) NewBatchAppender(ctx context.Context, opts BatchAppenderOptions) (*BatchAppender, error) {
+	opts.setDefaults(client.config)
+	handle, err := client.grpcClient.getConnectionHandleFor(ctx, NodePreferenceLeader)
+	if err != nil {
+		return nil, err
+	}
+
+	if !handle.SupportsFeature(featureBatchAppend) {
+		return nil, unsupportedFeatureError()
+	}
+
+	appender := &BatchAppender{
+		client:  client,
+		options: opts,
+		handle:  handle,
+		pending: make(map[uuid.UUID]*AppendFuture),
+		done:    make(chan struct{}),
+	}
+
+	streamsClient := api.NewStreamsClient(handle.Connection())
+	callOptions := []grpc.CallOption{grpc.Trailer(&appender.trailers)}
+	callOptions, ctx, cancel := configureGrpcCall(ctx, client.config, &opts, callOptions, client.credentials)
+
+	stream, err := streamsClient.BatchAppend(ctx, callOptions...)
+	if err != nil {
+		cancel()
+		err = client.grpcClient.handleError(handle, appender.trailers, err)
+		return nil, fmt.Errorf("could not construct batch append operation. Reason: %w", err)
+	}
+
+	appender.stream = stream
+	appender.cancel = cancel
+
+	go appender.receive()
+
+	return appender, nil
+}
+
+// Append sends events to a stream without waiting for the previous appends to complete. The returned future fails
+// with ErrorCodeDeadlineExceeded if the server does not answer within the append's deadline, or with ctx's error if
+// ctx is done first. Events larger than MaxBatchSize are sent in several messages but are still written atomically.
+func (appender *BatchAppender) Append(ctx context.Context, streamID string, opts BatchAppendOptions, events ...EventData) *AppendFuture {
+	opts.setDefaults(&appender.options)
+	correlation := uuid.New()
+	future := &AppendFuture{done: make(chan struct{})}
+
+	appender.mu.Lock()
+	if appender.err != nil {
+		err := appender.err
+		appender.mu.Unlock()
+		future.complete(nil, err)
+		return future
+	}
+
+	timer := time.AfterFunc(*opts.Deadline, func() {
+		appender.resolve(correlation, nil, &Error{
+			code: ErrorCodeDeadlineExceeded,
+			err:  fmt.Errorf("append to stream '%s' did not complete within %v", streamID, *opts.Deadline),
+		})
+	})
+
+	stop := context.AfterFunc(ctx, func() {
+		appender.resolve(correlation, nil, ctx.Err())
+	})
+
+	future.cleanup = func() {
+		timer.Stop()
+		stop()
+	}
+
+	appender.pending[correlation] = future
+	appender.mu.Unlock()
+
+	if err := appender.send(correlation, streamID, opts, events); err != nil {
+		// When the stream itself failed, the receiving side reports the actual cause to every pending append.
+		if !errors.Is(err, io.EOF) {
+			appender.resolve(correlation, nil, fmt.Errorf("could not send batch append request. Reason: %w", err))
+		}
+	}
+
+	return future
+}
+
+// Close stops accepting appends, waits for the pending ones to complete then closes the underlying stream.
+func (appender *BatchAppender) Close() error {
+	appender.mu.Lock()
+	if appender.err == nil {
+		appender.err = &Error{code: ErrorCodeConnectionClosed, err: fmt.Errorf("batch appender is closed")}
+	}
+
+	pending := make([]*AppendFuture, 0, len(appender.pending))
+	for _, future := range appender.pending {
+		pending = append(pending, future)
+	}
+	appender.mu.Unlock()
+
+	for _, future := range pending {
+		<-future.done
+	}
+
+	appender.sendMu.Lock()
+	_ = appender.stream.CloseSend()
+	appender.sendMu.Unlock()
+
+	appender.cancel()
+	<-appender.done
+
+	return nil
+}
+
+func (appender *BatchAppender) send(correlation uuid.UUID, streamID string, opts BatchAppendOptions, events []EventData) error {
+	options := toBatchAppendOptions(streamID, opts)
+	id := toProtoUUID(correlation)
+
+	appender.sendMu.Lock()
+	defer appender.sendMu.Unlock()
+
+	for start := 0; ; start += appender.options.MaxBatchSize {
+		end := min(start+appender.options.MaxBatchSize, len(events))
+		request := &api.BatchAppendReq{
+			CorrelationId:    id,
+			Options:          options,
+			ProposedMessages: make([]*api.BatchAppendReq_ProposedMessage, 0, end-start),
+			IsFinal:          end == len(events),
+		}
+
+		for _, event := range events[start:end] {
+			request.ProposedMessages = append(request.ProposedMessages, toBatchProposedMessage(event))
+		}
+
+		if err := appender.stream.Send(request); err != nil {
+			return err
+		}
+
+		if request.IsFinal {
+			return nil
+		}
+	}
+}
+
+func (appender *BatchAppender) receive() {
+	defer close(appender.done)
+
+	for {
+		response, err := appender.stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = &Error{code: ErrorCodeConnectionClosed, err: fmt.Errorf("batch append stream was closed by the server")}
+			} else {
+				err = appender.client.grpcClient.handleError(appender.handle, appender.trailers, err)
+			}
+
+			appender.failAll(err)
+			return
+		}
+
+		correlation, err := uuidFromProto(response.GetCorrelationId())
+		if err != nil {
+			appender.client.grpcClient.logger.withError(err).warn("received batch append response with an invalid correlation id, skipping",
+				LogKeyConnectionId, appender.handle.Id())
+			continue
+		}
+
+		result, err := batchAppendResult(response)
+		appender.resolve(correlation, result, err)
+	}
+}
+
+func (appender *BatchAppender) resolve(correlation uuid.UUID, result *WriteResult, err error) {
+	appender.mu.Lock()
+	future, ok := appender.pending[correlation]
+	delete(appender.pending, correlation)
+	appender.mu.Unlock()
+
+	if ok {
+		future.complete(result, err)
+	}
+}
+
+func (appender *BatchAppender) failAll(err error) {
+	appender.mu.Lock()
+	if appender.err == nil {
+		appender.err = err
+	}
+
+	pending := appender.pending
+	appender.pending = make(map[uuid.UUID]*AppendFuture)
+	appender.mu.Unlock()
+
+	for _, future := range pending {
+		future.complete(nil, err)
+	}
+}
+
+func toBatchAppendOptions(streamID string, opts BatchAppendOptions) *api.BatchAppendReq_Options {
+	options := &api.BatchAppendReq_Options{
+		StreamIdentifier: &shared.StreamIdentifier{
+			StreamName: []byte(streamID),
+		},
+		DeadlineOption: &api.BatchAppendReq_Options_Deadline{
+			Deadline: durationpb.New(*opts.Deadline),
+		},
+	}
+
+	switch value := opts.StreamState.(type) {
+	case Any:
+		options.ExpectedStreamPosition = &api.BatchAppendReq_Options_Any{Any: &emptypb.Empty{}}
+	case NoStream:
+		options.ExpectedStreamPosition = &api.BatchAppendReq_Options_NoStream{NoStream: &emptypb.Empty{}}
+	case StreamExists:
+		options.ExpectedStreamPosition = &api.BatchAppendReq_Options_StreamExists{StreamExists: &emptypb.Empty{}}
+	case StreamRevision:
+		options.ExpectedStreamPosition = &api.BatchAppendReq_Options_StreamPosition{StreamPosition: value.Value}
+	}
+
+	return options
+}
+
+func toBatchProposedMessage(event EventData) *api.BatchAppendReq_ProposedMessage {
+	message := toProposedMessage(event)
+
+	return &api.BatchAppendReq_ProposedMessage{
+		Id:             message.Id,
+		Metadata:       message.Metadata,
+		CustomMetadata: message.CustomMetadata,
+		Data:           message.Data,
+	}
+}
+
+func batchAppendResult(response *api.BatchAppendResp) (*WriteResult, error) {
+	if rpcStatus := response.GetError(); rpcStatus != nil {
+		return nil, batchAppendError(string(response.GetStreamIdentifier().GetStreamName()), grpcStatus.FromProto(rpcStatus))
+	}
+
+	success := response.GetSuccess()
+	if success == nil {
+		return nil, &Error{code: ErrorCodeInternalClient, err: fmt.Errorf("batch append response has no result")}
+	}
+
+	var streamRevision uint64
+	if _, ok := success.GetCurrentRevisionOption().(*api.BatchAppendResp_Success_NoStream); ok {
+		streamRevision = 1
+	} else {
+		streamRevision = success.GetCurrentRevision()
+	}
+
+	result := &WriteResult{NextExpectedVersion: streamRevision}
+	if position := success.GetPosition(); position != nil {
+		result.CommitPosition = position.CommitPosition
+		result.PreparePosition = position.PreparePosition
+	}
+
+	return result, nil
+}
+
+func batchAppendError(streamID string, status *grpcStatus.Status) error {
+	for _, d := range status.Details() {
+		switch detail := d.(type) {
+		case *shared.WrongExpectedVersion:
+			expected := ""
+			current := ""
+
+			if detail.GetExpectedAny() != nil {
+				expected = "any"
+			} else if detail.GetExpectedNoStream() != nil {
+				expected = "no_stream"
+			} else if detail.GetExpectedStreamExists() != nil {
+				expected = "stream_exists"
+			} else {
+				expected = strconv.FormatUint(detail.GetExpectedStreamPosition(), 10)
+			}
+
+			if detail.GetCurrentNoStream() != nil {
+				current = "no_stream"
+			} else {
+				current = strconv.FormatUint(detail.GetCurrentStreamRevision(), 10)
+			}
+
+			return &Error{code: ErrorCodeWrongExpectedVersion, err: fmt.Errorf("wrong expected version: expecting '%s' but got '%s'", expected, current)}
+		case *shared.StreamDeleted:
+			return &Error{code: ErrorCodeStreamDeleted, err: fmt.Errorf("stream '%s' is deleted", streamID)}
+		case *shared.AccessDenied:
+			return &Error{code: ErrorCodeAccessDenied, err: status.Err()}
+		case *shared.Timeout:
+			return &Error{code: ErrorCodeDeadlineExceeded, err: status.Err()}
+		case *shared.MaximumAppendSizeExceeded:
+			return &Error{code: ErrorCodeAppendTransactionSizeExceeded, err: fmt.Errorf("append to stream '%s' exceeds the maximum append size of %d bytes", streamID, detail.GetMaxAppendSize())}
+		}
+	}
+
+	return &Error{code: ErrorCodeUnknown, err: status.Err()}
+}
@@ -0,0 +1,155 @@
+package kurrentdb
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// InsecureCredentialsPolicy tells what happens to the credentials of a call when TLS is disabled, as they would be
+// sent in clear text.
+type InsecureCredentialsPolicy = string
+
+const (
+	// InsecureCredentialsWarn drops the credentials and logs a warning. Calls are made as the connection's user, if it
+	// has one and the policy let it through.
+	InsecureCredentialsWarn InsecureCredentialsPolicy = "warn"
+	// InsecureCredentialsError fails the calls carrying credentials with ErrorCodeUnauthenticated. Configuring
+	// credentials for the whole client is rejected by Configuration.Validate.
+	InsecureCredentialsError InsecureCredentialsPolicy = "error"
+	// InsecureCredentialsAllow sends the credentials anyway, for development setups that don't use TLS.
+	InsecureCredentialsAllow InsecureCredentialsPolicy = "allow"
+)
+
+// callCredentials resolves the credentials a call carries, for gRPC calls and HTTP requests alike: the ones in the
+// operation's options first, then the ones applied by Client.WithCredentials, then the ones of the configuration.
+type callCredentials struct {
+	// Credentials applied by Client.WithCredentials.
+	scoped *Credentials
+	// Credentials of the configuration, already checked against the policy. Nil if there are none.
+	connection credentials.PerRPCCredentials
+	insecure   bool
+	policy     InsecureCredentialsPolicy
+	logger     *logger
+}
+
+func newCallCredentials(conf *Configuration, logger *logger) *callCredentials {
+	creds := &callCredentials{
+		insecure: conf.DisableTLS,
+		policy:   conf.InsecureCredentials,
+		logger:   logger,
+	}
+
+	var connection credentials.PerRPCCredentials
+	if conf.TokenSource != nil {
+		connection = newCredentialsPerRPCCredentials(&Credentials{Token: conf.TokenSource})
+	} else if conf.CredentialsProvider != nil {
+		connection = &providedPerRPCCredentials{provider: conf.CredentialsProvider}
+	} else if conf.Username != "" && conf.Password != "" {
+		connection = newBasicAuthPerRPCCredentials(conf.Username, conf.Password)
+	}
+
+	if connection == nil || !creds.insecure {
+		creds.connection = connection
+		return creds
+	}
+
+	switch creds.policy {
+	case InsecureCredentialsAllow:
+		creds.connection = &insecurePerRPCCredentials{connection}
+	case InsecureCredentialsError:
+		// Rejected by Validate.
+	default:
+		logger.warn("TLS is disabled: the credentials of the configuration are not sent, set insecureCredentials=allow to send them in clear text")
+	}
+
+	return creds
+}
+
+// withScope returns a copy of the credentials applying creds to every call.
+func (c *callCredentials) withScope(creds *Credentials) *callCredentials {
+	scoped := *c
+	scoped.scoped = creds
+	return &scoped
+}
+
+// forCall returns the credentials of a call, nil if it carries none. It fails if the call carries credentials the
+// policy doesn't let through.
+func (c *callCredentials) forCall(explicit *Credentials) (credentials.PerRPCCredentials, error) {
+	creds := explicit
+	if creds == nil {
+		creds = c.scoped
+	}
+
+	if creds == nil {
+		return c.connection, nil
+	}
+
+	perRPCCredentials := newCredentialsPerRPCCredentials(creds)
+	if !c.insecure {
+		return perRPCCredentials, nil
+	}
+
+	switch c.policy {
+	case InsecureCredentialsAllow:
+		return &insecurePerRPCCredentials{perRPCCredentials}, nil
+	case InsecureCredentialsError:
+		return nil, status.Error(codes.Unauthenticated, "credentials can't be sent without TLS, set insecureCredentials=allow to send them in clear text")
+	default:
+		c.logger.warn("TLS is disabled: the credentials of the call are not sent, set insecureCredentials=allow to send them in clear text")
+		return c.connection, nil
+	}
+}
+
+// grpcCredentials is forCall for gRPC calls, which fail with the policy's error when they are made.
+func (c *callCredentials) grpcCredentials(explicit *Credentials) credentials.PerRPCCredentials {
+	perRPCCredentials, err := c.forCall(explicit)
+	if err != nil {
+		return &failingPerRPCCredentials{err: err}
+	}
+
+	return perRPCCredentials
+}
+
+// httpAuthorization is forCall for HTTP requests, returning the value of their authorization header, empty if none.
+func (c *callCredentials) httpAuthorization(ctx context.Context, explicit *Credentials) (string, error) {
+	perRPCCredentials, err := c.forCall(explicit)
+	if err != nil {
+		return "", &Error{code: ErrorCodeUnauthenticated, err: err}
+	}
+
+	if perRPCCredentials == nil {
+		return "", nil
+	}
+
+	headers, err := perRPCCredentials.GetRequestMetadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return headers["Authorization"], nil
+}
+
+// insecurePerRPCCredentials lets credentials be sent without TLS.
+type insecurePerRPCCredentials struct {
+	credentials.PerRPCCredentials
+}
+
+func (*insecurePerRPCCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// failingPerRPCCredentials fails the calls it is attached to.
+type failingPerRPCCredentials struct {
+	err error
+}
+
+func (f *failingPerRPCCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return nil, f.err
+}
+
+func (*failingPerRPCCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -0,0 +1,220 @@
+package kurrentdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Checkpoint progress of a catch-up subscription.
+type Checkpoint struct {
+	// Transaction log position, set for subscriptions to $all.
+	Position *Position
+	// Stream revision, set for subscriptions to a stream.
+	Revision *uint64
+}
+
+// CheckpointStore persists the progress of a catch-up subscription.
+type CheckpointStore interface {
+	// Load returns the last stored checkpoint, or nil if none was stored yet.
+	Load(ctx context.Context) (*Checkpoint, error)
+	// Store persists a checkpoint.
+	Store(ctx context.Context, checkpoint Checkpoint) error
+}
+
+const checkpointEventType = "Checkpoint"
+
+type checkpointJson struct {
+	Commit   *uint64 `json:"commit,omitempty"`
+	Prepare  *uint64 `json:"prepare,omitempty"`
+	Revision *uint64 `json:"revision,omitempty"`
+}
+
+func (checkpoint Checkpoint) toJson() ([]byte, error) {
+	var payload checkpointJson
+
+	if checkpoint.Position != nil {
+		payload.Commit = &checkpoint.Position.Commit
+		payload.Prepare = &checkpoint.Position.Prepare
+	}
+
+	payload.Revision = checkpoint.Revision
+
+	return json.Marshal(payload)
+}
+
+func checkpointFromJson(data []byte) (*Checkpoint, error) {
+	var payload checkpointJson
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, &Error{code: ErrorCodeParsing, err: fmt.Errorf("error when deserializing checkpoint json: %w", err)}
+	}
+
+	checkpoint := Checkpoint{Revision: payload.Revision}
+
+	if payload.Commit != nil && payload.Prepare != nil {
+		checkpoint.Position = &Position{Commit: *payload.Commit, Prepare: *payload.Prepare}
+	}
+
+	return &checkpoint, nil
+}
+
+// StreamCheckpointStore stores checkpoints as events appended to a KurrentDB stream. Appends use the revision of the
+// last checkpoint read or written as expected revision, so two consumers sharing the same stream fail with
+// ErrorCodeWrongExpectedVersion instead of overwriting each other's progress. Consider setting a $maxCount on the
+// stream, as only its last event is used.
+type StreamCheckpointStore struct {
+	client   *Client
+	streamID string
+	mu       sync.Mutex
+	state    StreamState
+}
+
+// NewStreamCheckpointStore creates a checkpoint store backed by the given stream.
+func NewStreamCheckpointStore(client *Client, streamID string) *StreamCheckpointStore {
+	return &StreamCheckpointStore{
+		client:   client,
+		streamID: streamID,
+	}
+}
+
+// Load reads the last checkpoint event of the stream.
+func (store *StreamCheckpointStore) Load(ctx context.Context) (*Checkpoint, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	stream, err := store.client.ReadStream(ctx, store.streamID, ReadStreamOptions{
+		Direction: Backwards,
+		From:      End{},
+	}, 1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer stream.Close()
+	event, err := stream.Recv()
+
+	if errors.Is(err, io.EOF) {
+		store.state = NoStream{}
+		return nil, nil
+	}
+
+	if err != nil {
+		var esErr *Error
+		if errors.As(err, &esErr) && esErr.IsErrorCode(ErrorCodeResourceNotFound) {
+			store.state = NoStream{}
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	store.state = event.OriginalStreamRevision()
+
+	return checkpointFromJson(event.OriginalEvent().Data)
+}
+
+// Store appends a checkpoint event to the stream.
+func (store *StreamCheckpointStore) Store(ctx context.Context, checkpoint Checkpoint) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	data, err := checkpoint.toJson()
+	if err != nil {
+		return fmt.Errorf("error when serializing checkpoint: %w", err)
+	}
+
+	state := store.state
+	if state == nil {
+		state = Any{}
+	}
+
+	result, err := store.client.AppendToStream(ctx, store.streamID, AppendToStreamOptions{
+		StreamState: state,
+	}, EventData{
+		ContentType: ContentTypeJson,
+		EventType:   checkpointEventType,
+		Data:        data,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	store.state = Revision(result.NextExpectedVersion)
+
+	return nil
+}
+
+// FileCheckpointStore stores the last checkpoint in a local file. The file is replaced atomically on every store.
+type FileCheckpointStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileCheckpointStore creates a checkpoint store backed by the file at the given path.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{
+		path: path,
+	}
+}
+
+// Load reads the checkpoint file.
+func (store *FileCheckpointStore) Load(_ context.Context) (*Checkpoint, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	data, err := os.ReadFile(store.path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error when reading checkpoint file: %w", err)
+	}
+
+	return checkpointFromJson(data)
+}
+
+// Store writes the checkpoint to a temporary file then renames it to the checkpoint file.
+func (store *FileCheckpointStore) Store(_ context.Context, checkpoint Checkpoint) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	data, err := checkpoint.toJson()
+	if err != nil {
+		return fmt.Errorf("error when serializing checkpoint: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(store.path), filepath.Base(store.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error when creating checkpoint file: %w", err)
+	}
+
+	defer os.Remove(file.Name())
+
+	if _, err = file.Write(data); err == nil {
+		err = file.Sync()
+	}
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("error when writing checkpoint file: %w", err)
+	}
+
+	if err = os.Rename(file.Name(), store.path); err != nil {
+		return fmt.Errorf("error when writing checkpoint file: %w", err)
+	}
+
+	return nil
+}
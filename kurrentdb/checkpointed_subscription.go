@@ -0,0 +1,186 @@
+package kurrentdb
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// CheckpointOptions options of the checkpointed subscriptions.
+type CheckpointOptions struct {
+	// Number of handled events after which a checkpoint is stored. Checkpoints sent by the server are always stored.
+	// Defaults to 100.
+	Interval int
+	// Context used when storing checkpoints. Defaults to the context of the subscription.
+	Context context.Context
+}
+
+func (o *CheckpointOptions) setDefaults(ctx context.Context) {
+	if o.Interval <= 0 {
+		o.Interval = 100
+	}
+
+	if o.Context == nil {
+		o.Context = ctx
+	}
+}
+
+// CheckpointedSubscription is a catch-up subscription which stores its progress in a CheckpointStore.
+//
+// An event is considered handled once Recv is called again, so the checkpoint never goes past an event the consumer
+// has not finished processing. This gives at-least-once delivery: after a restart, up to Interval events may be
+// delivered again.
+type CheckpointedSubscription struct {
+	inner   *Subscription
+	store   CheckpointStore
+	options CheckpointOptions
+	last    *Checkpoint
+	pending bool
+	handled int
+}
+
+// SubscribeToAllWithCheckpoint subscribes to $all starting after the checkpoint loaded from the store, or from
+// opts.From if the store is empty.
+func (client *Client) SubscribeToAllWithCheckpoint(
+	ctx context.Context,
+	store CheckpointStore,
+	opts SubscribeToAllOptions,
+	checkpointOpts CheckpointOptions,
+) (*CheckpointedSubscription, error) {
+	checkpoint, err := store.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint. Reason: %w", err)
+	}
+
+	if checkpoint != nil && checkpoint.Position != nil {
+		opts.From = *checkpoint.Position
+	}
+
+	subscription, err := client.SubscribeToAll(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpointOpts.setDefaults(ctx)
+
+	return newCheckpointedSubscription(subscription, store, checkpointOpts), nil
+}
+
+// SubscribeToStreamWithCheckpoint subscribes to a stream starting after the checkpoint loaded from the store, or from
+// opts.From if the store is empty.
+func (client *Client) SubscribeToStreamWithCheckpoint(
+	ctx context.Context,
+	streamID string,
+	store CheckpointStore,
+	opts SubscribeToStreamOptions,
+	checkpointOpts CheckpointOptions,
+) (*CheckpointedSubscription, error) {
+	checkpoint, err := store.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint. Reason: %w", err)
+	}
+
+	if checkpoint != nil && checkpoint.Revision != nil {
+		opts.From = Revision(*checkpoint.Revision)
+	}
+
+	subscription, err := client.SubscribeToStream(ctx, streamID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpointOpts.setDefaults(ctx)
+
+	return newCheckpointedSubscription(subscription, store, checkpointOpts), nil
+}
+
+func newCheckpointedSubscription(inner *Subscription, store CheckpointStore, options CheckpointOptions) *CheckpointedSubscription {
+	return &CheckpointedSubscription{
+		inner:   inner,
+		store:   store,
+		options: options,
+	}
+}
+
+// Id returns subscription's id.
+func (sub *CheckpointedSubscription) Id() string {
+	return sub.inner.Id()
+}
+
+// Close drops the subscription and cleans up allocated resources. Pending progress is not stored, call Commit first
+// to keep it.
+func (sub *CheckpointedSubscription) Close() error {
+	return sub.inner.Close()
+}
+
+// Recv marks the previously received event as handled and awaits for the next incoming subscription's event. If
+// storing a checkpoint fails, the subscription is dropped with that error.
+func (sub *CheckpointedSubscription) Recv() *SubscriptionEvent {
+	if sub.pending {
+		sub.pending = false
+		sub.handled += 1
+	}
+
+	if sub.handled >= sub.options.Interval {
+		if err := sub.Commit(); err != nil {
+			return sub.fail(err)
+		}
+	}
+
+	event := sub.inner.Recv()
+
+	switch {
+	case event.EventAppeared != nil:
+		if original := event.EventAppeared.OriginalEvent(); original != nil {
+			position := original.Position
+			revision := original.EventNumber
+			sub.last = &Checkpoint{Position: &position, Revision: &revision}
+			sub.pending = true
+		}
+	case event.CheckPointReached != nil:
+		position := *event.CheckPointReached
+		sub.last = &Checkpoint{Position: &position}
+		sub.handled = 0
+
+		if err := sub.store.Store(sub.options.Context, *sub.last); err != nil {
+			return sub.fail(err)
+		}
+
+		sub.last = nil
+	}
+
+	return event
+}
+
+// Commit stores the position of the last event returned by Recv, which must have been handled. It does nothing if
+// there is no progress since the last commit.
+func (sub *CheckpointedSubscription) Commit() error {
+	if sub.last == nil {
+		return nil
+	}
+
+	if err := sub.store.Store(sub.options.Context, *sub.last); err != nil {
+		return err
+	}
+
+	sub.last = nil
+	sub.handled = 0
+
+	return nil
+}
+
+// Events returns an iterator over the events of the subscription, with the same semantics as Subscription.Events.
+// An event is considered handled once the loop body moves on to the next one.
+func (sub *CheckpointedSubscription) Events() iter.Seq2[*ResolvedEvent, error] {
+	return subscriptionEvents(sub.Recv, sub.Close)
+}
+
+func (sub *CheckpointedSubscription) fail(err error) *SubscriptionEvent {
+	_ = sub.inner.Close()
+
+	return &SubscriptionEvent{
+		SubscriptionDropped: &SubscriptionDropped{
+			Error: fmt.Errorf("failed to store checkpoint. Reason: %w", err),
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package kurrentdb
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// circuitBreaker stops operations from piling up behind discovery while no node can be reached. Once discovery fails,
+// the circuit opens and operations fail fast with ErrorCodeCircuitOpen for the configured cooldown. The circuit then
+// half-opens: the next operation runs discovery again, which closes the circuit if it succeeds and opens it for
+// another cooldown otherwise. Operations queued behind that probe wait for its outcome.
+type circuitBreaker struct {
+	cooldown  time.Duration
+	mu        sync.Mutex
+	openUntil time.Time
+	lastErr   error
+}
+
+func newCircuitBreaker(cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{cooldown: cooldown}
+}
+
+// check returns an ErrorCodeCircuitOpen error while the circuit is open, nil when it is closed or half-open.
+func (breaker *circuitBreaker) check() error {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	if breaker.lastErr == nil || !time.Now().Before(breaker.openUntil) {
+		return nil
+	}
+
+	return &Error{
+		code: ErrorCodeCircuitOpen,
+		err: fmt.Errorf("retrying discovery in %v, last error: %w",
+			time.Until(breaker.openUntil).Round(time.Millisecond), breaker.lastErr),
+	}
+}
+
+// trip opens the circuit after a failed discovery. It does nothing when the cooldown is zero, in which case every
+// operation runs discovery again.
+func (breaker *circuitBreaker) trip(err error) {
+	if breaker.cooldown <= 0 {
+		return
+	}
+
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	breaker.openUntil = time.Now().Add(breaker.cooldown)
+	breaker.lastErr = err
+}
+
+// reset closes the circuit after a successful discovery.
+func (breaker *circuitBreaker) reset() {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	breaker.openUntil = time.Time{}
+	breaker.lastErr = nil
+}
@@ -9,7 +9,9 @@ import (
 	"io"
 	"iter"
 	"strconv"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/gossip"
 	persistentProto "github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/persistent"
 	"github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/shared"
@@ -23,8 +25,9 @@ import (
 // Client Represents a client to a single node. A client instance maintains a full duplex communication to KurrentDB.
 // Many threads can use a KurrentDB client at the same time or a single thread can make many asynchronous requests.
 type Client struct {
-	grpcClient *grpcClient
-	config     *Configuration
+	grpcClient  *grpcClient
+	config      *Configuration
+	credentials *callCredentials
 }
 
 // NewClient Creates a gRPC client to a KurrentDB database.
@@ -35,11 +38,35 @@ func NewClient(configuration *Configuration) (*Client, error) {
 
 	grpcClient := newGrpcClient(*configuration)
 	return &Client{
-		grpcClient: grpcClient,
-		config:     configuration,
+		grpcClient:  grpcClient,
+		config:      configuration,
+		credentials: grpcClient.credentials,
 	}, nil
 }
 
+// WithCredentials returns a view of the client making every call as the user of creds, unless an operation's options
+// carry their own credentials. The view shares the connection of the client, closing either closes both.
+func (client *Client) WithCredentials(creds *Credentials) *Client {
+	return &Client{
+		grpcClient:  client.grpcClient,
+		config:      client.config,
+		credentials: client.credentials.withScope(creds),
+	}
+}
+
+// Connect eagerly discovers a node and connects to it, rather than waiting for the first operation to do so. It lets
+// an application fail fast at startup when the database can't be reached within the context's deadline. Calling it
+// on a connected client is a no-op.
+//
+// If the context is done before a node is found, Connect gives up without closing the client, and the next operation
+// starts a new discovery process.
+func (client *Client) Connect(ctx context.Context) error {
+	return tracedErr(ctx, client.grpcClient.telemetry, "client.connect", nil, func(ctx context.Context) error {
+		_, err := client.grpcClient.getConnectionHandle(ctx)
+		return err
+	})
+}
+
 // Close Closes a connection and cleans all its allocated resources.
 func (client *Client) Close() error {
 	client.grpcClient.close()
@@ -47,21 +74,86 @@ func (client *Client) Close() error {
 }
 
 // AppendToStream Appends events to a given stream.
+//
+// When the configuration has a RetryPolicy, the append is retried after a transient failure if it is idempotent,
+// meaning it has an explicit StreamState other than Any and all its events have an EventID.
 func (client *Client) AppendToStream(
-	context context.Context,
+	ctx context.Context,
 	streamID string,
 	opts AppendToStreamOptions,
 	events ...EventData,
 ) (*WriteResult, error) {
 	opts.setDefaults()
-	handle, err := client.grpcClient.getConnectionHandle()
+	idempotent := isIdempotentWrite(opts.StreamState, events...)
+
+	return traced(ctx, client.grpcClient.telemetry, "streams.append", streamAttributes(streamID, opts.StreamState), func(ctx context.Context) (*WriteResult, error) {
+		events := injectTraceContext(ctx, client.config, events)
+		start := time.Now()
+
+		result, err := withRetry(ctx, client, idempotent, func() (*WriteResult, error) {
+			return client.appendToStream(ctx, streamID, opts, events...)
+		})
+
+		client.grpcClient.telemetry.recordAppend(ctx, start, err)
+		return result, err
+	})
+}
+
+// AppendEvents serializes values of types registered in the configuration's Serializer and appends them to a given
+// stream. EventData values are appended as they are. It fails with ErrorCodeUnknownEventType before sending anything
+// if a value's type is not registered.
+func (client *Client) AppendEvents(
+	ctx context.Context,
+	streamID string,
+	state StreamState,
+	events ...any,
+) (*WriteResult, error) {
+	serializer := client.serializer()
+
+	data := make([]EventData, 0, len(events))
+	for _, event := range events {
+		if eventData, ok := event.(EventData); ok {
+			data = append(data, eventData)
+			continue
+		}
+
+		eventData, err := serializer.Serialize(event)
+		if err != nil {
+			return nil, err
+		}
+
+		data = append(data, eventData)
+	}
+
+	return client.AppendToStream(ctx, streamID, AppendToStreamOptions{StreamState: state}, data...)
+}
+
+// serializer returns the configuration's Serializer, DefaultSerializer if none is set.
+func (client *Client) serializer() *Serializer {
+	if client.config.Serializer == nil {
+		return DefaultSerializer
+	}
+
+	return client.config.Serializer
+}
+
+func (client *Client) appendToStream(
+	context context.Context,
+	streamID string,
+	opts AppendToStreamOptions,
+	events ...EventData,
+) (*WriteResult, error) {
+	context, cancelDeadline := withOperationDeadline(context, client.config, &opts)
+	defer cancelDeadline()
+
+	handle, err := client.grpcClient.getConnectionHandleFor(context, NodePreferenceLeader)
 	if err != nil {
 		return nil, err
 	}
 	streamsClient := api.NewStreamsClient(handle.Connection())
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(context, client.config, &opts, callOptions, client.grpcClient.perRPCCredentials)
+	callOptions, ctx, cancel := configureGrpcCall(context, client.config, &opts, callOptions, client.credentials)
 	defer cancel()
 
 	appendOperation, err := streamsClient.Append(ctx, callOptions...)
@@ -186,6 +278,18 @@ func (client *Client) AppendToStream(
 //
 // Note: Currently, metadata must be valid JSON with string keys and string values. Binary metadata will not be supported in this version. This limitation ensures compatibility with KurrentDB's metadata handling and will be removed in the next major release.
 func (client *Client) MultiStreamAppend(
+	ctx context.Context,
+	requests iter.Seq[AppendStreamRequest],
+) (*MultiStreamAppendResponse, error) {
+	return traced(ctx, client.grpcClient.telemetry, "streams.multi_stream_append", nil, func(ctx context.Context) (*MultiStreamAppendResponse, error) {
+		start := time.Now()
+		response, err := client.multiStreamAppend(ctx, requests)
+		client.grpcClient.telemetry.recordAppend(ctx, start, err)
+		return response, err
+	})
+}
+
+func (client *Client) multiStreamAppend(
 	context context.Context,
 	requests iter.Seq[AppendStreamRequest],
 ) (*MultiStreamAppendResponse, error) {
@@ -203,7 +307,10 @@ func (client *Client) MultiStreamAppend(
 		}
 	}
 
-	handle, err := client.grpcClient.getConnectionHandle()
+	context, cancelDeadline := withOperationDeadline(context, client.config, &opts)
+	defer cancelDeadline()
+
+	handle, err := client.grpcClient.getConnectionHandleFor(context, NodePreferenceLeader)
 	if err != nil {
 		return nil, err
 	}
@@ -215,9 +322,11 @@ func (client *Client) MultiStreamAppend(
 	streamsClient := apiV2.NewStreamsServiceClient(handle.Connection())
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall_(context, client.config, &opts, callOptions, client.grpcClient.perRPCCredentials, false)
+	callOptions, ctx, cancel := configureGrpcCall_(context, client.config, &opts, callOptions, client.credentials, false)
 	defer cancel()
 
+	traceProperties := traceMetadata(context, client.config)
+
 	appendOperation, err := streamsClient.AppendSession(ctx, callOptions...)
 	if err != nil {
 		err = client.grpcClient.handleError(handle, trailers, err)
@@ -242,6 +351,9 @@ func (client *Client) MultiStreamAppend(
 			for key, value := range metadataProperties {
 				properties[key] = value
 			}
+			for key, value := range traceProperties {
+				properties[key] = structpb.NewStringValue(value)
+			}
 
 			recordId := event.EventID.String()
 
@@ -309,6 +421,19 @@ func (client *Client) MultiStreamAppend(
 //   - ErrorCodeAppendTransactionSizeExceeded: Total transaction too large (*AppendTransactionSizeExceededError)
 //   - ErrorCodeUnsupportedFeature: Server does not support AppendRecords
 func (client *Client) AppendRecords(
+	ctx context.Context,
+	records []AppendRecord,
+	checks ...ConsistencyCheck,
+) (*AppendRecordsResponse, error) {
+	return traced(ctx, client.grpcClient.telemetry, "streams.append_records", nil, func(ctx context.Context) (*AppendRecordsResponse, error) {
+		start := time.Now()
+		response, err := client.appendRecords(ctx, records, checks...)
+		client.grpcClient.telemetry.recordAppend(ctx, start, err)
+		return response, err
+	})
+}
+
+func (client *Client) appendRecords(
 	context context.Context,
 	records []AppendRecord,
 	checks ...ConsistencyCheck,
@@ -326,7 +451,11 @@ func (client *Client) AppendRecords(
 		}
 	}
 
-	handle, err := client.grpcClient.getConnectionHandle()
+	var opts appendRecordsOptions
+	context, cancelDeadline := withOperationDeadline(context, client.config, &opts)
+	defer cancelDeadline()
+
+	handle, err := client.grpcClient.getConnectionHandleFor(context, NodePreferenceLeader)
 	if err != nil {
 		return nil, err
 	}
@@ -335,13 +464,13 @@ func (client *Client) AppendRecords(
 		return nil, unsupportedFeatureError()
 	}
 
-	var opts appendRecordsOptions
 	streamsClient := apiV2.NewStreamsServiceClient(handle.Connection())
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall_(context, client.config, &opts, callOptions, client.grpcClient.perRPCCredentials, false)
+	callOptions, ctx, cancel := configureGrpcCall_(context, client.config, &opts, callOptions, client.credentials, false)
 	defer cancel()
 
+	traceProperties := traceMetadata(context, client.config)
 	protoRecords := make([]*apiV2.AppendRecord, 0, len(records))
 	for _, record := range records {
 		properties := make(map[string]*structpb.Value)
@@ -358,6 +487,9 @@ func (client *Client) AppendRecords(
 		for key, value := range metadataProperties {
 			properties[key] = value
 		}
+		for key, value := range traceProperties {
+			properties[key] = structpb.NewStringValue(value)
+		}
 
 		recordId := record.Record.EventID.String()
 
@@ -417,6 +549,17 @@ func (client *Client) AppendRecords(
 
 // SetStreamMetadata Sets the metadata for a stream.
 func (client *Client) SetStreamMetadata(
+	ctx context.Context,
+	streamID string,
+	opts AppendToStreamOptions,
+	metadata StreamMetadata,
+) (*WriteResult, error) {
+	return traced(ctx, client.grpcClient.telemetry, "streams.set_metadata", streamAttributes(streamID, opts.StreamState), func(ctx context.Context) (*WriteResult, error) {
+		return client.setStreamMetadata(ctx, streamID, opts, metadata)
+	})
+}
+
+func (client *Client) setStreamMetadata(
 	context context.Context,
 	streamID string,
 	opts AppendToStreamOptions,
@@ -435,7 +578,9 @@ func (client *Client) SetStreamMetadata(
 		return nil, fmt.Errorf("error when serializing stream metadata: %w", err)
 	}
 
+	// A stable event id makes the append idempotent, so it can be retried.
 	result, err := client.AppendToStream(context, streamName, opts, EventData{
+		EventID:     uuid.New(),
 		ContentType: ContentTypeJson,
 		EventType:   "$metadata",
 		Data:        data,
@@ -450,6 +595,16 @@ func (client *Client) SetStreamMetadata(
 
 // GetStreamMetadata Reads the metadata for a stream.
 func (client *Client) GetStreamMetadata(
+	ctx context.Context,
+	streamID string,
+	opts ReadStreamOptions,
+) (*StreamMetadata, error) {
+	return traced(ctx, client.grpcClient.telemetry, "streams.get_metadata", streamAttributes(streamID, nil), func(ctx context.Context) (*StreamMetadata, error) {
+		return client.getStreamMetadata(ctx, streamID, opts)
+	})
+}
+
+func (client *Client) getStreamMetadata(
 	context context.Context,
 	streamID string,
 	opts ReadStreamOptions,
@@ -501,23 +656,31 @@ func (client *Client) DeleteStream(
 	opts DeleteStreamOptions,
 ) (*DeleteResult, error) {
 	opts.setDefaults()
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return nil, err
-	}
-	streamsClient := api.NewStreamsClient(handle.Connection())
-	var headers, trailers metadata.MD
-	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(parent, client.config, &opts, callOptions, client.grpcClient.perRPCCredentials)
-	defer cancel()
-	deleteRequest := toDeleteRequest(streamID, opts.StreamState)
-	deleteResponse, err := streamsClient.Delete(ctx, deleteRequest, callOptions...)
-	if err != nil {
-		err = client.grpcClient.handleError(handle, trailers, err)
-		return nil, fmt.Errorf("failed to perform delete, details: %w", err)
-	}
 
-	return &DeleteResult{Position: deletePositionFromProto(deleteResponse)}, nil
+	return traced(parent, client.grpcClient.telemetry, "streams.delete", streamAttributes(streamID, opts.StreamState), func(parent context.Context) (*DeleteResult, error) {
+		return withRetry(parent, client, isIdempotentWrite(opts.StreamState), func() (*DeleteResult, error) {
+			parent, cancelDeadline := withOperationDeadline(parent, client.config, &opts)
+			defer cancelDeadline()
+
+			handle, err := client.grpcClient.getConnectionHandleFor(parent, NodePreferenceLeader)
+			if err != nil {
+				return nil, err
+			}
+			streamsClient := api.NewStreamsClient(handle.Connection())
+			var headers, trailers metadata.MD
+			callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
+			callOptions, ctx, cancel := configureGrpcCall(parent, client.config, &opts, callOptions, client.credentials)
+			defer cancel()
+			deleteRequest := toDeleteRequest(streamID, opts.StreamState)
+			deleteResponse, err := streamsClient.Delete(ctx, deleteRequest, callOptions...)
+			if err != nil {
+				err = client.grpcClient.handleError(handle, trailers, err)
+				return nil, fmt.Errorf("failed to perform delete, details: %w", err)
+			}
+
+			return &DeleteResult{Position: deletePositionFromProto(deleteResponse)}, nil
+		})
+	})
 }
 
 // TombstoneStream Permanently deletes a given stream by writing a Tombstone event to the end of the stream.
@@ -531,58 +694,109 @@ func (client *Client) TombstoneStream(
 	opts TombstoneStreamOptions,
 ) (*DeleteResult, error) {
 	opts.setDefaults()
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return nil, err
-	}
-	streamsClient := api.NewStreamsClient(handle.Connection())
-	var headers, trailers metadata.MD
-	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(parent, client.config, &opts, callOptions, client.grpcClient.perRPCCredentials)
-	defer cancel()
-	tombstoneRequest := toTombstoneRequest(streamID, opts.StreamState)
-	tombstoneResponse, err := streamsClient.Tombstone(ctx, tombstoneRequest, callOptions...)
 
-	if err != nil {
-		err = client.grpcClient.handleError(handle, trailers, err)
-		return nil, fmt.Errorf("failed to perform delete, details: %w", err)
-	}
+	return traced(parent, client.grpcClient.telemetry, "streams.tombstone", streamAttributes(streamID, opts.StreamState), func(parent context.Context) (*DeleteResult, error) {
+		return withRetry(parent, client, isIdempotentWrite(opts.StreamState), func() (*DeleteResult, error) {
+			parent, cancelDeadline := withOperationDeadline(parent, client.config, &opts)
+			defer cancelDeadline()
+
+			handle, err := client.grpcClient.getConnectionHandleFor(parent, NodePreferenceLeader)
+			if err != nil {
+				return nil, err
+			}
+			streamsClient := api.NewStreamsClient(handle.Connection())
+			var headers, trailers metadata.MD
+			callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
+			callOptions, ctx, cancel := configureGrpcCall(parent, client.config, &opts, callOptions, client.credentials)
+			defer cancel()
+			tombstoneRequest := toTombstoneRequest(streamID, opts.StreamState)
+			tombstoneResponse, err := streamsClient.Tombstone(ctx, tombstoneRequest, callOptions...)
+
+			if err != nil {
+				err = client.grpcClient.handleError(handle, trailers, err)
+				return nil, fmt.Errorf("failed to perform delete, details: %w", err)
+			}
 
-	return &DeleteResult{Position: tombstonePositionFromProto(tombstoneResponse)}, nil
+			return &DeleteResult{Position: tombstonePositionFromProto(tombstoneResponse)}, nil
+		})
+	})
 }
 
 // ReadStream Reads events from a given stream. The reading can be done forward and backward.
 func (client *Client) ReadStream(
-	context context.Context,
+	ctx context.Context,
 	streamID string,
 	opts ReadStreamOptions,
 	count uint64,
 ) (*ReadStream, error) {
 	opts.setDefaults()
-	readRequest := toReadStreamRequest(streamID, opts.Direction, opts.From, count, opts.ResolveLinkTos)
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return nil, err
-	}
-	streamsClient := api.NewStreamsClient(handle.Connection())
 
-	return readInternal(context, client, &opts, handle, streamsClient, readRequest)
+	return traced(ctx, client.grpcClient.telemetry, "streams.read", streamAttributes(streamID, nil), func(ctx context.Context) (*ReadStream, error) {
+		readRequest := toReadStreamRequest(streamID, opts.Direction, opts.From, count, opts.ResolveLinkTos)
+		ctx, cancelDeadline := withOperationDeadline(ctx, client.config, &opts)
+		handle, err := client.grpcClient.getConnectionHandleFor(ctx, preferenceOf(&opts))
+		if err != nil {
+			cancelDeadline()
+			return nil, err
+		}
+		streamsClient := api.NewStreamsClient(handle.Connection())
+
+		stream, err := readInternal(ctx, client, &opts, handle, streamsClient, readRequest)
+		if err != nil {
+			cancelDeadline()
+		}
+
+		return stream, err
+	})
 }
 
-// ReadAll Reads events from the $all stream. The reading can be done forward and backward.
+// ReadAll Reads events from the $all stream. The reading can be done forward and backward. When a filter is set, the
+// count applies to the events matching the filter and the server periodically yields checkpoints, see
+// ReadStream.RecvMessage.
 func (client *Client) ReadAll(
-	context context.Context,
+	ctx context.Context,
 	opts ReadAllOptions,
 	count uint64,
 ) (*ReadStream, error) {
 	opts.setDefaults()
-	handle, err := client.grpcClient.getConnectionHandle()
+
+	return traced(ctx, client.grpcClient.telemetry, "streams.read_all", nil, func(ctx context.Context) (*ReadStream, error) {
+		return client.readAll(ctx, opts, count)
+	})
+}
+
+func (client *Client) readAll(
+	context context.Context,
+	opts ReadAllOptions,
+	count uint64,
+) (*ReadStream, error) {
+	var filterOptions *SubscriptionFilterOptions = nil
+	if opts.Filter != nil {
+		filterOptions = &SubscriptionFilterOptions{
+			MaxSearchWindow:    opts.MaxSearchWindow,
+			CheckpointInterval: opts.CheckpointInterval,
+			SubscriptionFilter: opts.Filter,
+		}
+	}
+
+	readRequest, err := toReadAllRequest(opts.Direction, opts.From, count, opts.ResolveLinkTos, filterOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct read operation. Reason: %w", err)
+	}
+
+	context, cancelDeadline := withOperationDeadline(context, client.config, &opts)
+	handle, err := client.grpcClient.getConnectionHandleFor(context, preferenceOf(&opts))
 	if err != nil {
+		cancelDeadline()
 		return nil, err
 	}
 	streamsClient := api.NewStreamsClient(handle.Connection())
-	readRequest := toReadAllRequest(opts.Direction, opts.From, count, opts.ResolveLinkTos)
-	return readInternal(context, client, &opts, handle, streamsClient, readRequest)
+	stream, err := readInternal(context, client, &opts, handle, streamsClient, readRequest)
+	if err != nil {
+		cancelDeadline()
+	}
+
+	return stream, err
 }
 
 // SubscribeToStream allows you to subscribe to a stream and receive notifications about new events added to the stream.
@@ -595,40 +809,30 @@ func (client *Client) SubscribeToStream(
 	opts SubscribeToStreamOptions,
 ) (*Subscription, error) {
 	opts.setDefaults()
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return nil, err
-	}
-	var headers, trailers metadata.MD
-	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(parent, client.config, &opts, callOptions, client.grpcClient.perRPCCredentials)
-
-	streamsClient := api.NewStreamsClient(handle.Connection())
 	subscriptionRequest, err := toStreamSubscriptionRequest(streamID, opts.From, opts.ResolveLinkTos, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct subscription. Reason: %w", err)
 	}
-	readClient, err := streamsClient.Read(ctx, subscriptionRequest, callOptions...)
-	if err != nil {
-		defer cancel()
-		err = client.grpcClient.handleError(handle, trailers, err)
-		return nil, fmt.Errorf("failed to construct subscription. Reason: %w", err)
-	}
-	readResult, err := readClient.Recv()
-	if err != nil {
-		defer cancel()
-		err = client.grpcClient.handleError(handle, trailers, err)
-		return nil, fmt.Errorf("failed to perform read. Reason: %w", err)
-	}
-	switch readResult.Content.(type) {
-	case *api.ReadResp_Confirmation:
-		{
-			confirmation := readResult.GetConfirmation()
-			return newSubscription(client, cancel, readClient, confirmation.SubscriptionId), nil
+
+	var resubscribe *resubscribeParams
+	if opts.Resubscribe != nil {
+		resubscribe = &resubscribeParams{
+			options: &opts,
+			policy:  *opts.Resubscribe,
+			request: func(checkpoint subscriptionCheckpoint) (*api.ReadReq, error) {
+				from := opts.From
+				if checkpoint.revision != nil {
+					from = Revision(*checkpoint.revision)
+				}
+
+				return toStreamSubscriptionRequest(streamID, from, opts.ResolveLinkTos, nil)
+			},
 		}
 	}
-	defer cancel()
-	return nil, fmt.Errorf("failed to initiate subscription")
+
+	return traced(parent, client.grpcClient.telemetry, "streams.subscribe", streamAttributes(streamID, nil), func(parent context.Context) (*Subscription, error) {
+		return client.subscribeInternal(parent, &opts, subscriptionRequest, resubscribe)
+	})
 }
 
 // SubscribeToAll allows you to subscribe to $all stream and receive notifications about new events added to the stream.
@@ -640,14 +844,6 @@ func (client *Client) SubscribeToAll(
 	opts SubscribeToAllOptions,
 ) (*Subscription, error) {
 	opts.setDefaults()
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return nil, err
-	}
-	streamsClient := api.NewStreamsClient(handle.Connection())
-	var headers, trailers metadata.MD
-	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(parent, client.config, &opts, callOptions, client.grpcClient.perRPCCredentials)
 
 	var filterOptions *SubscriptionFilterOptions = nil
 	if opts.Filter != nil {
@@ -662,6 +858,76 @@ func (client *Client) SubscribeToAll(
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct subscription. Reason: %w", err)
 	}
+
+	var resubscribe *resubscribeParams
+	if opts.Resubscribe != nil {
+		resubscribe = &resubscribeParams{
+			options: &opts,
+			policy:  *opts.Resubscribe,
+			request: func(checkpoint subscriptionCheckpoint) (*api.ReadReq, error) {
+				from := opts.From
+				if checkpoint.position != nil {
+					from = *checkpoint.position
+				}
+
+				return toAllSubscriptionRequest(from, opts.ResolveLinkTos, filterOptions)
+			},
+		}
+	}
+
+	return traced(parent, client.grpcClient.telemetry, "streams.subscribe_all", nil, func(parent context.Context) (*Subscription, error) {
+		return client.subscribeInternal(parent, &opts, subscriptionRequest, resubscribe)
+	})
+}
+
+func (client *Client) subscribeInternal(
+	parent context.Context,
+	options options,
+	subscriptionRequest *api.ReadReq,
+	resubscribe *resubscribeParams,
+) (*Subscription, error) {
+	if resubscribe == nil {
+		stream, err := client.openSubscription(parent, options, subscriptionRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		return newSubscription(client, stream.cancel, stream, nil), nil
+	}
+
+	// Every resubscription attempt derives from that context so closing the subscription cancels all of them.
+	ctx, cancel := context.WithCancel(parent)
+	resubscribe.ctx = ctx
+
+	stream, err := client.openSubscription(ctx, options, subscriptionRequest)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return newSubscription(client, cancel, stream, resubscribe), nil
+}
+
+func (client *Client) openSubscription(
+	parent context.Context,
+	options options,
+	subscriptionRequest *api.ReadReq,
+) (*subscriptionStream, error) {
+	parent, cancelDeadline := withOperationDeadline(parent, client.config, options)
+	handle, err := client.grpcClient.getConnectionHandleFor(parent, preferenceOf(options))
+	if err != nil {
+		cancelDeadline()
+		return nil, err
+	}
+	streamsClient := api.NewStreamsClient(handle.Connection())
+	var headers, trailers metadata.MD
+	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
+	callOptions, ctx, cancelCall := configureGrpcCall(parent, client.config, options, callOptions, client.credentials)
+	cancel := func() {
+		cancelCall()
+		cancelDeadline()
+	}
+
 	readClient, err := streamsClient.Read(ctx, subscriptionRequest, callOptions...)
 	if err != nil {
 		defer cancel()
@@ -678,7 +944,13 @@ func (client *Client) SubscribeToAll(
 	case *api.ReadResp_Confirmation:
 		{
 			confirmation := readResult.GetConfirmation()
-			return newSubscription(client, cancel, readClient, confirmation.SubscriptionId), nil
+			return &subscriptionStream{
+				id:       confirmation.SubscriptionId,
+				handle:   handle,
+				inner:    readClient,
+				cancel:   cancel,
+				trailers: &trailers,
+			}, nil
 		}
 	}
 	defer cancel()
@@ -693,22 +965,32 @@ func (client *Client) SubscribeToPersistentSubscription(
 	options SubscribeToPersistentSubscriptionOptions,
 ) (*PersistentSubscription, error) {
 	options.setDefaults()
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return nil, err
-	}
 
-	persistentSubscriptionClient := newPersistentClient(client.grpcClient, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
-
-	return persistentSubscriptionClient.ConnectToPersistentSubscription(
-		ctx,
-		client.config,
-		&options,
-		handle,
-		int32(options.BufferSize),
-		streamName,
-		groupName,
-	)
+	return traced(ctx, client.grpcClient.telemetry, "persistent_subscriptions.subscribe", persistentAttributes(streamName, groupName), func(ctx context.Context) (*PersistentSubscription, error) {
+		ctx, cancelDeadline := withOperationDeadline(ctx, client.config, &options)
+		handle, err := client.grpcClient.getConnectionHandleFor(ctx, NodePreferenceLeader)
+		if err != nil {
+			cancelDeadline()
+			return nil, err
+		}
+
+		persistentSubscriptionClient := newPersistentClient(client.grpcClient, client.credentials, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
+
+		subscription, err := persistentSubscriptionClient.ConnectToPersistentSubscription(
+			ctx,
+			client.config,
+			&options,
+			handle,
+			int32(options.BufferSize),
+			streamName,
+			groupName,
+		)
+		if err != nil {
+			cancelDeadline()
+		}
+
+		return subscription, err
+	})
 }
 
 // SubscribeToPersistentSubscriptionToAll Connects to a persistent subscription group to the $all stream.
@@ -718,25 +1000,36 @@ func (client *Client) SubscribeToPersistentSubscriptionToAll(
 	options SubscribeToPersistentSubscriptionOptions,
 ) (*PersistentSubscription, error) {
 	options.setDefaults()
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return nil, err
-	}
 
-	if !handle.SupportsFeature(featurePersistentSubscriptionToAll) {
-		return nil, unsupportedFeatureError()
-	}
-	persistentSubscriptionClient := newPersistentClient(client.grpcClient, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
-
-	return persistentSubscriptionClient.ConnectToPersistentSubscription(
-		ctx,
-		client.config,
-		&options,
-		handle,
-		int32(options.BufferSize),
-		"",
-		groupName,
-	)
+	return traced(ctx, client.grpcClient.telemetry, "persistent_subscriptions.subscribe", persistentAttributes("$all", groupName), func(ctx context.Context) (*PersistentSubscription, error) {
+		ctx, cancelDeadline := withOperationDeadline(ctx, client.config, &options)
+		handle, err := client.grpcClient.getConnectionHandleFor(ctx, NodePreferenceLeader)
+		if err != nil {
+			cancelDeadline()
+			return nil, err
+		}
+
+		if !handle.SupportsFeature(featurePersistentSubscriptionToAll) {
+			cancelDeadline()
+			return nil, unsupportedFeatureError()
+		}
+		persistentSubscriptionClient := newPersistentClient(client.grpcClient, client.credentials, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
+
+		subscription, err := persistentSubscriptionClient.ConnectToPersistentSubscription(
+			ctx,
+			client.config,
+			&options,
+			handle,
+			int32(options.BufferSize),
+			"",
+			groupName,
+		)
+		if err != nil {
+			cancelDeadline()
+		}
+
+		return subscription, err
+	})
 }
 
 // CreatePersistentSubscription Creates a persistent subscription gorup on a stream.
@@ -750,19 +1043,26 @@ func (client *Client) CreatePersistentSubscription(
 	groupName string,
 	options PersistentStreamSubscriptionOptions,
 ) error {
-	options.setDefaults()
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return err
-	}
-	persistentSubscriptionClient := newPersistentClient(client.grpcClient, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
+	return tracedErr(ctx, client.grpcClient.telemetry, "persistent_subscriptions.create", persistentAttributes(streamName, groupName), func(ctx context.Context) error {
+		return withNotLeaderRetryErr(ctx, client, func() error {
+			options.setDefaults()
+			ctx, cancelDeadline := withOperationDeadline(ctx, client.config, &options)
+			defer cancelDeadline()
 
-	if options.Settings == nil {
-		setts := SubscriptionSettingsDefault()
-		options.Settings = &setts
-	}
+			handle, err := client.grpcClient.getConnectionHandleFor(ctx, NodePreferenceLeader)
+			if err != nil {
+				return err
+			}
+			persistentSubscriptionClient := newPersistentClient(client.grpcClient, client.credentials, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
 
-	return persistentSubscriptionClient.CreateStreamSubscription(ctx, client.config, &options, handle, streamName, groupName, options.StartFrom, *options.Settings)
+			if options.Settings == nil {
+				setts := SubscriptionSettingsDefault()
+				options.Settings = &setts
+			}
+
+			return persistentSubscriptionClient.CreateStreamSubscription(ctx, client.config, &options, handle, streamName, groupName, options.StartFrom, *options.Settings)
+		})
+	})
 }
 
 // CreatePersistentSubscriptionToAll Creates a persistent subscription gorup on the $all stream.
@@ -775,39 +1075,46 @@ func (client *Client) CreatePersistentSubscriptionToAll(
 	groupName string,
 	options PersistentAllSubscriptionOptions,
 ) error {
-	options.setDefaults()
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return err
-	}
+	return tracedErr(ctx, client.grpcClient.telemetry, "persistent_subscriptions.create", persistentAttributes("$all", groupName), func(ctx context.Context) error {
+		return withNotLeaderRetryErr(ctx, client, func() error {
+			options.setDefaults()
+			ctx, cancelDeadline := withOperationDeadline(ctx, client.config, &options)
+			defer cancelDeadline()
 
-	if !handle.SupportsFeature(featurePersistentSubscriptionToAll) {
-		return unsupportedFeatureError()
-	}
-	var filterOptions *SubscriptionFilterOptions = nil
-	if options.Filter != nil {
-		filterOptions = &SubscriptionFilterOptions{
-			MaxSearchWindow:    options.MaxSearchWindow,
-			SubscriptionFilter: options.Filter,
-		}
-	}
-	persistentSubscriptionClient := newPersistentClient(client.grpcClient, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
+			handle, err := client.grpcClient.getConnectionHandleFor(ctx, NodePreferenceLeader)
+			if err != nil {
+				return err
+			}
 
-	if options.Settings == nil {
-		setts := SubscriptionSettingsDefault()
-		options.Settings = &setts
-	}
+			if !handle.SupportsFeature(featurePersistentSubscriptionToAll) {
+				return unsupportedFeatureError()
+			}
+			var filterOptions *SubscriptionFilterOptions = nil
+			if options.Filter != nil {
+				filterOptions = &SubscriptionFilterOptions{
+					MaxSearchWindow:    options.MaxSearchWindow,
+					SubscriptionFilter: options.Filter,
+				}
+			}
+			persistentSubscriptionClient := newPersistentClient(client.grpcClient, client.credentials, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
+
+			if options.Settings == nil {
+				setts := SubscriptionSettingsDefault()
+				options.Settings = &setts
+			}
 
-	return persistentSubscriptionClient.CreateAllSubscription(
-		ctx,
-		client.config,
-		&options,
-		handle,
-		groupName,
-		options.StartFrom,
-		*options.Settings,
-		filterOptions,
-	)
+			return persistentSubscriptionClient.CreateAllSubscription(
+				ctx,
+				client.config,
+				&options,
+				handle,
+				groupName,
+				options.StartFrom,
+				*options.Settings,
+				filterOptions,
+			)
+		})
+	})
 }
 
 // UpdatePersistentSubscription Updates a persistent subscription group on a stream.
@@ -817,19 +1124,26 @@ func (client *Client) UpdatePersistentSubscription(
 	groupName string,
 	options PersistentStreamSubscriptionOptions,
 ) error {
-	options.setDefaults()
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return err
-	}
-	persistentSubscriptionClient := newPersistentClient(client.grpcClient, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
+	return tracedErr(ctx, client.grpcClient.telemetry, "persistent_subscriptions.update", persistentAttributes(streamName, groupName), func(ctx context.Context) error {
+		return withRetryErr(ctx, client, true, func() error {
+			options.setDefaults()
+			ctx, cancelDeadline := withOperationDeadline(ctx, client.config, &options)
+			defer cancelDeadline()
 
-	if options.Settings == nil {
-		setts := SubscriptionSettingsDefault()
-		options.Settings = &setts
-	}
+			handle, err := client.grpcClient.getConnectionHandleFor(ctx, NodePreferenceLeader)
+			if err != nil {
+				return err
+			}
+			persistentSubscriptionClient := newPersistentClient(client.grpcClient, client.credentials, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
 
-	return persistentSubscriptionClient.UpdateStreamSubscription(ctx, client.config, &options, handle, streamName, groupName, options.StartFrom, *options.Settings)
+			if options.Settings == nil {
+				setts := SubscriptionSettingsDefault()
+				options.Settings = &setts
+			}
+
+			return persistentSubscriptionClient.UpdateStreamSubscription(ctx, client.config, &options, handle, streamName, groupName, options.StartFrom, *options.Settings)
+		})
+	})
 }
 
 // UpdatePersistentSubscriptionToAll Updates a persistent subscription group on the $all stream.
@@ -838,19 +1152,26 @@ func (client *Client) UpdatePersistentSubscriptionToAll(
 	groupName string,
 	options PersistentAllSubscriptionOptions,
 ) error {
-	options.setDefaults()
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return err
-	}
+	return tracedErr(ctx, client.grpcClient.telemetry, "persistent_subscriptions.update", persistentAttributes("$all", groupName), func(ctx context.Context) error {
+		return withRetryErr(ctx, client, true, func() error {
+			options.setDefaults()
+			ctx, cancelDeadline := withOperationDeadline(ctx, client.config, &options)
+			defer cancelDeadline()
 
-	if !handle.SupportsFeature(featurePersistentSubscriptionToAll) {
-		return unsupportedFeatureError()
-	}
+			handle, err := client.grpcClient.getConnectionHandleFor(ctx, NodePreferenceLeader)
+			if err != nil {
+				return err
+			}
+
+			if !handle.SupportsFeature(featurePersistentSubscriptionToAll) {
+				return unsupportedFeatureError()
+			}
 
-	persistentSubscriptionClient := newPersistentClient(client.grpcClient, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
+			persistentSubscriptionClient := newPersistentClient(client.grpcClient, client.credentials, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
 
-	return persistentSubscriptionClient.UpdateAllSubscription(ctx, client.config, &options, handle, groupName, options.StartFrom, *options.Settings)
+			return persistentSubscriptionClient.UpdateAllSubscription(ctx, client.config, &options, handle, groupName, options.StartFrom, *options.Settings)
+		})
+	})
 }
 
 // DeletePersistentSubscription Deletes a persistent subscription group on a stream.
@@ -860,13 +1181,20 @@ func (client *Client) DeletePersistentSubscription(
 	groupName string,
 	options DeletePersistentSubscriptionOptions,
 ) error {
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return err
-	}
-	persistentSubscriptionClient := newPersistentClient(client.grpcClient, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
+	return tracedErr(ctx, client.grpcClient.telemetry, "persistent_subscriptions.delete", persistentAttributes(streamName, groupName), func(ctx context.Context) error {
+		return withNotLeaderRetryErr(ctx, client, func() error {
+			ctx, cancelDeadline := withOperationDeadline(ctx, client.config, &options)
+			defer cancelDeadline()
+
+			handle, err := client.grpcClient.getConnectionHandleFor(ctx, NodePreferenceLeader)
+			if err != nil {
+				return err
+			}
+			persistentSubscriptionClient := newPersistentClient(client.grpcClient, client.credentials, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
 
-	return persistentSubscriptionClient.DeleteStreamSubscription(ctx, client.config, &options, handle, streamName, groupName)
+			return persistentSubscriptionClient.DeleteStreamSubscription(ctx, client.config, &options, handle, streamName, groupName)
+		})
+	})
 }
 
 // DeletePersistentSubscriptionToAll Deletes a persistent subscription group on the $all stream.
@@ -875,18 +1203,25 @@ func (client *Client) DeletePersistentSubscriptionToAll(
 	groupName string,
 	options DeletePersistentSubscriptionOptions,
 ) error {
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return err
-	}
+	return tracedErr(ctx, client.grpcClient.telemetry, "persistent_subscriptions.delete", persistentAttributes("$all", groupName), func(ctx context.Context) error {
+		return withNotLeaderRetryErr(ctx, client, func() error {
+			ctx, cancelDeadline := withOperationDeadline(ctx, client.config, &options)
+			defer cancelDeadline()
 
-	if !handle.SupportsFeature(featurePersistentSubscriptionToAll) {
-		return unsupportedFeatureError()
-	}
+			handle, err := client.grpcClient.getConnectionHandleFor(ctx, NodePreferenceLeader)
+			if err != nil {
+				return err
+			}
+
+			if !handle.SupportsFeature(featurePersistentSubscriptionToAll) {
+				return unsupportedFeatureError()
+			}
 
-	persistentSubscriptionClient := newPersistentClient(client.grpcClient, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
+			persistentSubscriptionClient := newPersistentClient(client.grpcClient, client.credentials, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
 
-	return persistentSubscriptionClient.DeleteAllSubscription(ctx, client.config, &options, handle, groupName)
+			return persistentSubscriptionClient.DeleteAllSubscription(ctx, client.config, &options, handle, groupName)
+		})
+	})
 }
 
 // ReplayParkedMessages Replays the parked messages of a persistent subscription to a stream.
@@ -900,26 +1235,33 @@ func (client *Client) ReplayParkedMessagesToAll(ctx context.Context, groupName s
 }
 
 func (client *Client) replayParkedMessages(ctx context.Context, streamName string, groupName string, options ReplayParkedMessagesOptions) error {
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return err
-	}
+	return tracedErr(ctx, client.grpcClient.telemetry, "persistent_subscriptions.replay_parked", persistentAttributes(streamName, groupName), func(ctx context.Context) error {
+		return withNotLeaderRetryErr(ctx, client, func() error {
+			ctx, cancelDeadline := withOperationDeadline(ctx, client.config, &options)
+			defer cancelDeadline()
 
-	var finalStreamName *string
-	if streamName != "$all" {
-		finalStreamName = &streamName
-	}
+			handle, err := client.grpcClient.getConnectionHandleFor(ctx, NodePreferenceLeader)
+			if err != nil {
+				return err
+			}
 
-	if finalStreamName == nil && !handle.SupportsFeature(featurePersistentSubscriptionToAll) {
-		return unsupportedFeatureError()
-	}
+			var finalStreamName *string
+			if streamName != "$all" {
+				finalStreamName = &streamName
+			}
 
-	if handle.SupportsFeature(featurePersistentSubscriptionManagement) {
-		persistentSubscriptionClient := newPersistentClient(client.grpcClient, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
-		return persistentSubscriptionClient.replayParkedMessages(ctx, client.config, handle, finalStreamName, groupName, &options)
-	}
+			if finalStreamName == nil && !handle.SupportsFeature(featurePersistentSubscriptionToAll) {
+				return unsupportedFeatureError()
+			}
+
+			if handle.SupportsFeature(featurePersistentSubscriptionManagement) {
+				persistentSubscriptionClient := newPersistentClient(client.grpcClient, client.credentials, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
+				return persistentSubscriptionClient.replayParkedMessages(ctx, client.config, handle, finalStreamName, groupName, &options)
+			}
 
-	return client.httpReplayParkedMessages(streamName, groupName, options)
+			return client.httpReplayParkedMessages(ctx, streamName, groupName, options)
+		})
+	})
 }
 
 // ListAllPersistentSubscriptions Lists all persistent subscriptions regardless of which stream they are on.
@@ -939,25 +1281,32 @@ func (client *Client) ListPersistentSubscriptionsToAll(ctx context.Context, opti
 }
 
 func (client *Client) listPersistentSubscriptionsInternal(ctx context.Context, streamName *string, options ListPersistentSubscriptionsOptions) ([]PersistentSubscriptionInfo, error) {
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return nil, err
-	}
+	return traced(ctx, client.grpcClient.telemetry, "persistent_subscriptions.list", nil, func(ctx context.Context) ([]PersistentSubscriptionInfo, error) {
+		return withRetry(ctx, client, true, func() ([]PersistentSubscriptionInfo, error) {
+			ctx, cancelDeadline := withOperationDeadline(ctx, client.config, &options)
+			defer cancelDeadline()
 
-	if streamName != nil && *streamName == "$all" && !handle.SupportsFeature(featurePersistentSubscriptionToAll) {
-		return nil, unsupportedFeatureError()
-	}
+			handle, err := client.grpcClient.getConnectionHandleFor(ctx, NodePreferenceLeader)
+			if err != nil {
+				return nil, err
+			}
 
-	if handle.SupportsFeature(featurePersistentSubscriptionManagement) {
-		persistentSubscriptionClient := newPersistentClient(client.grpcClient, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
-		return persistentSubscriptionClient.listPersistentSubscriptions(ctx, client.config, handle, streamName, &options)
-	}
+			if streamName != nil && *streamName == "$all" && !handle.SupportsFeature(featurePersistentSubscriptionToAll) {
+				return nil, unsupportedFeatureError()
+			}
 
-	if streamName != nil {
-		return client.httpListPersistentSubscriptionsForStream(*streamName, options)
-	}
+			if handle.SupportsFeature(featurePersistentSubscriptionManagement) {
+				persistentSubscriptionClient := newPersistentClient(client.grpcClient, client.credentials, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
+				return persistentSubscriptionClient.listPersistentSubscriptions(ctx, client.config, handle, streamName, &options)
+			}
+
+			if streamName != nil {
+				return client.httpListPersistentSubscriptionsForStream(ctx, *streamName, options)
+			}
 
-	return client.httpListAllPersistentSubscriptions(options)
+			return client.httpListAllPersistentSubscriptions(ctx, options)
+		})
+	})
 }
 
 // GetPersistentSubscriptionInfo Gets the info for a specific persistent subscription to a stream
@@ -971,42 +1320,56 @@ func (client *Client) GetPersistentSubscriptionInfoToAll(ctx context.Context, gr
 }
 
 func (client *Client) getPersistentSubscriptionInfoInternal(ctx context.Context, streamName *string, groupName string, options GetPersistentSubscriptionOptions) (*PersistentSubscriptionInfo, error) {
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return nil, err
-	}
+	return traced(ctx, client.grpcClient.telemetry, "persistent_subscriptions.get_info", nil, func(ctx context.Context) (*PersistentSubscriptionInfo, error) {
+		return withRetry(ctx, client, true, func() (*PersistentSubscriptionInfo, error) {
+			ctx, cancelDeadline := withOperationDeadline(ctx, client.config, &options)
+			defer cancelDeadline()
 
-	if streamName != nil && *streamName == "$all" && !handle.SupportsFeature(featurePersistentSubscriptionToAll) {
-		return nil, unsupportedFeatureError()
+			handle, err := client.grpcClient.getConnectionHandleFor(ctx, NodePreferenceLeader)
+			if err != nil {
+				return nil, err
+			}
 
-	}
+			if streamName != nil && *streamName == "$all" && !handle.SupportsFeature(featurePersistentSubscriptionToAll) {
+				return nil, unsupportedFeatureError()
 
-	if handle.SupportsFeature(featurePersistentSubscriptionManagement) {
-		persistentSubscriptionClient := newPersistentClient(client.grpcClient, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
-		return persistentSubscriptionClient.getPersistentSubscriptionInfo(ctx, client.config, handle, streamName, groupName, &options)
-	}
+			}
 
-	if streamName == nil {
-		streamName = new(string)
-		*streamName = "$all"
-	}
+			if handle.SupportsFeature(featurePersistentSubscriptionManagement) {
+				persistentSubscriptionClient := newPersistentClient(client.grpcClient, client.credentials, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
+				return persistentSubscriptionClient.getPersistentSubscriptionInfo(ctx, client.config, handle, streamName, groupName, &options)
+			}
+
+			if streamName == nil {
+				streamName = new(string)
+				*streamName = "$all"
+			}
 
-	return client.httpGetPersistentSubscriptionInfo(*streamName, groupName, options)
+			return client.httpGetPersistentSubscriptionInfo(ctx, *streamName, groupName, options)
+		})
+	})
 }
 
 // RestartPersistentSubscriptionSubsystem Restarts the persistent subscription subsystem on the server.
 func (client *Client) RestartPersistentSubscriptionSubsystem(ctx context.Context, options RestartPersistentSubscriptionSubsystemOptions) error {
-	handle, err := client.grpcClient.getConnectionHandle()
-	if err != nil {
-		return err
-	}
+	return tracedErr(ctx, client.grpcClient.telemetry, "persistent_subscriptions.restart_subsystem", nil, func(ctx context.Context) error {
+		return withNotLeaderRetryErr(ctx, client, func() error {
+			ctx, cancelDeadline := withOperationDeadline(ctx, client.config, &options)
+			defer cancelDeadline()
 
-	if handle.SupportsFeature(featurePersistentSubscriptionManagement) {
-		persistentClient := newPersistentClient(client.grpcClient, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
-		return persistentClient.restartSubsystem(ctx, client.config, handle, &options)
-	}
+			handle, err := client.grpcClient.getConnectionHandleFor(ctx, NodePreferenceLeader)
+			if err != nil {
+				return err
+			}
+
+			if handle.SupportsFeature(featurePersistentSubscriptionManagement) {
+				persistentClient := newPersistentClient(client.grpcClient, client.credentials, persistentProto.NewPersistentSubscriptionsClient(handle.Connection()))
+				return persistentClient.restartSubsystem(ctx, client.config, handle, &options)
+			}
 
-	return client.httpRestartSubsystem(options)
+			return client.httpRestartSubsystem(ctx, options)
+		})
+	})
 }
 
 func readInternal(
@@ -1019,7 +1382,7 @@ func readInternal(
 ) (*ReadStream, error) {
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(parent, client.config, options, callOptions, client.grpcClient.perRPCCredentials)
+	callOptions, ctx, cancel := configureGrpcCall(parent, client.config, options, callOptions, client.credentials)
 	result, err := streamsClient.Read(ctx, readRequest, callOptions...)
 	if err != nil {
 		defer cancel()
@@ -1040,21 +1403,26 @@ func readInternal(
 	return newReadStream(params), nil
 }
 
+// Gossip reads the members of the cluster from the gossip of the node the client is connected to.
+//
+// Deprecated: use ReadCluster, which describes the members without exposing protobuf types.
 func (client *Client) Gossip(ctx context.Context) ([]*gossip.MemberInfo, error) {
-	handle, err := client.grpcClient.getConnectionHandle()
+	return traced(ctx, client.grpcClient.telemetry, "gossip.read", nil, func(ctx context.Context) ([]*gossip.MemberInfo, error) {
+		handle, err := client.grpcClient.getConnectionHandle(ctx)
 
-	if err != nil {
-		return nil, err
-	}
+		if err != nil {
+			return nil, err
+		}
 
-	gossipClient := gossip.NewGossipClient(handle.Connection())
-	clusterInfo, err := gossipClient.Read(ctx, &shared.Empty{})
+		gossipClient := gossip.NewGossipClient(handle.Connection())
+		clusterInfo, err := gossipClient.Read(ctx, &shared.Empty{})
 
-	if err != nil {
-		return nil, err
-	}
+		if err != nil {
+			return nil, err
+		}
 
-	return clusterInfo.Members, nil
+		return clusterInfo.Members, nil
+	})
 }
 
 func mapMetadataToValue(metadata []byte) (map[string]*structpb.Value, error) {
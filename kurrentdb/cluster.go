@@ -0,0 +1,250 @@
+package kurrentdb
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	gossipApi "github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/gossip"
+	"github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/shared"
+)
+
+// NodeState state of a cluster node, as reported by gossip.
+type NodeState string
+
+const (
+	NodeStateInitializing       NodeState = "Initializing"
+	NodeStateDiscoverLeader     NodeState = "DiscoverLeader"
+	NodeStateUnknown            NodeState = "Unknown"
+	NodeStatePreReplica         NodeState = "PreReplica"
+	NodeStateCatchingUp         NodeState = "CatchingUp"
+	NodeStateClone              NodeState = "Clone"
+	NodeStateFollower           NodeState = "Follower"
+	NodeStatePreLeader          NodeState = "PreLeader"
+	NodeStateLeader             NodeState = "Leader"
+	NodeStateManager            NodeState = "Manager"
+	NodeStateShuttingDown       NodeState = "ShuttingDown"
+	NodeStateShutdown           NodeState = "Shutdown"
+	NodeStateReadOnlyLeaderless NodeState = "ReadOnlyLeaderless"
+	NodeStatePreReadOnlyReplica NodeState = "PreReadOnlyReplica"
+	NodeStateReadOnlyReplica    NodeState = "ReadOnlyReplica"
+	NodeStateResigningLeader    NodeState = "ResigningLeader"
+)
+
+func nodeStateFromProto(state gossipApi.MemberInfo_VNodeState) NodeState {
+	return NodeState(state.String())
+}
+
+// ClusterMember is a node of a cluster, as reported by gossip.
+type ClusterMember struct {
+	// Unique identifier of the node.
+	InstanceId uuid.UUID
+	// State of the node.
+	State NodeState
+	// Whether the node is considered alive by the cluster.
+	IsAlive bool
+	// Endpoint clients connect to.
+	HttpEndPoint EndPoint
+	// Time at which the node last updated its gossip.
+	TimeStamp time.Time
+}
+
+// ClusterInfo describes the members of a cluster.
+type ClusterInfo struct {
+	Members []ClusterMember
+}
+
+// Leader returns the alive leader of the cluster, if any.
+func (info *ClusterInfo) Leader() (ClusterMember, bool) {
+	members := info.membersIn(NodeStateLeader)
+	if len(members) == 0 {
+		return ClusterMember{}, false
+	}
+
+	return members[0], true
+}
+
+// Followers returns the alive followers of the cluster.
+func (info *ClusterInfo) Followers() []ClusterMember {
+	return info.membersIn(NodeStateFollower)
+}
+
+// ReadOnlyReplicas returns the alive read-only replicas of the cluster.
+func (info *ClusterInfo) ReadOnlyReplicas() []ClusterMember {
+	return info.membersIn(NodeStateReadOnlyReplica)
+}
+
+// Member returns the member with the given instance id, if any.
+func (info *ClusterInfo) Member(instanceId uuid.UUID) (ClusterMember, bool) {
+	for _, member := range info.Members {
+		if member.InstanceId == instanceId {
+			return member, true
+		}
+	}
+
+	return ClusterMember{}, false
+}
+
+func (info *ClusterInfo) membersIn(state NodeState) []ClusterMember {
+	members := make([]ClusterMember, 0)
+	for _, member := range info.Members {
+		if member.IsAlive && member.State == state {
+			members = append(members, member)
+		}
+	}
+
+	return members
+}
+
+func clusterInfoFromProto(wire *gossipApi.ClusterInfo) (*ClusterInfo, error) {
+	info := &ClusterInfo{Members: make([]ClusterMember, 0, len(wire.GetMembers()))}
+
+	for _, member := range wire.GetMembers() {
+		instanceId, err := uuidFromProto(member.GetInstanceId())
+		if err != nil {
+			return nil, &Error{code: ErrorCodeParsing, err: err}
+		}
+
+		info.Members = append(info.Members, ClusterMember{
+			InstanceId: instanceId,
+			State:      nodeStateFromProto(member.GetState()),
+			IsAlive:    member.GetIsAlive(),
+			HttpEndPoint: EndPoint{
+				Host: member.GetHttpEndPoint().GetAddress(),
+				Port: uint16(member.GetHttpEndPoint().GetPort()),
+			},
+			// The timestamp is the number of .NET "ticks" (100ns increments) since the UNIX epoch.
+			TimeStamp: time.Unix(0, member.GetTimeStamp()*100).UTC(),
+		})
+	}
+
+	return info, nil
+}
+
+// ReadCluster reads the members of the cluster from the gossip of the node the client is connected to.
+func (client *Client) ReadCluster(ctx context.Context) (*ClusterInfo, error) {
+	return traced(ctx, client.grpcClient.telemetry, "gossip.read", nil, func(ctx context.Context) (*ClusterInfo, error) {
+		handle, err := client.grpcClient.getConnectionHandle(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		wire, err := gossipApi.NewGossipClient(handle.Connection()).Read(ctx, &shared.Empty{})
+		if err != nil {
+			return nil, client.grpcClient.handleError(handle, nil, err)
+		}
+
+		return clusterInfoFromProto(wire)
+	})
+}
+
+// ClusterChange describes how a cluster changed between two gossip reads of WatchCluster.
+type ClusterChange struct {
+	// Cluster as of the latest gossip read.
+	Cluster *ClusterInfo
+	// Members that appeared since the previous read. On the first read, every member.
+	Added []ClusterMember
+	// Members that disappeared since the previous read.
+	Removed []ClusterMember
+	// Members whose state, liveness or endpoint changed since the previous read.
+	Changed []ClusterMemberChange
+	// Error of the gossip read, in which case the other fields are empty. The watch keeps polling.
+	Error error
+}
+
+// ClusterMemberChange describes a member before and after a change.
+type ClusterMemberChange struct {
+	Previous ClusterMember
+	Current  ClusterMember
+}
+
+// defaultWatchClusterInterval is the interval of WatchCluster when neither the caller nor the configuration give one.
+const defaultWatchClusterInterval = 5 * time.Second
+
+// WatchCluster reads gossip every interval and publishes the changes of the cluster. The first change lists every
+// member as added, subsequent ones are only published when a member appears, disappears or changes. The channel is
+// closed when ctx is done or the client is closed.
+//
+// An interval of zero or less falls back to the configuration's GossipRefreshInterval, or 5 seconds if that is not set
+// either.
+func (client *Client) WatchCluster(ctx context.Context, interval time.Duration) <-chan ClusterChange {
+	if interval <= 0 {
+		interval = client.config.GossipRefreshInterval
+	}
+
+	if interval <= 0 {
+		interval = defaultWatchClusterInterval
+	}
+
+	changes := make(chan ClusterChange)
+
+	go func() {
+		defer close(changes)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var previous *ClusterInfo
+		for {
+			current, err := client.ReadCluster(ctx)
+
+			var change ClusterChange
+			switch {
+			case client.grpcClient.isClosed() || ctx.Err() != nil:
+				return
+			case err != nil:
+				change = ClusterChange{Error: err}
+			default:
+				change = diffCluster(previous, current)
+				previous = current
+			}
+
+			if change.Error != nil || len(change.Added) > 0 || len(change.Removed) > 0 || len(change.Changed) > 0 {
+				select {
+				case changes <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes
+}
+
+// diffCluster compares two gossip reads, members being matched by instance id.
+func diffCluster(previous *ClusterInfo, current *ClusterInfo) ClusterChange {
+	change := ClusterChange{Cluster: current}
+
+	if previous == nil {
+		change.Added = current.Members
+		return change
+	}
+
+	for _, member := range current.Members {
+		before, ok := previous.Member(member.InstanceId)
+		if !ok {
+			change.Added = append(change.Added, member)
+			continue
+		}
+
+		if before.State != member.State || before.IsAlive != member.IsAlive || before.HttpEndPoint != member.HttpEndPoint {
+			change.Changed = append(change.Changed, ClusterMemberChange{Previous: before, Current: member})
+		}
+	}
+
+	for _, member := range previous.Members {
+		if _, ok := current.Member(member.InstanceId); !ok {
+			change.Removed = append(change.Removed, member)
+		}
+	}
+
+	return change
+}
@@ -1,14 +1,20 @@
 package kurrentdb
 
 import (
+	"context"
 	"crypto/x509"
+	"errors"
 	"fmt"
+	"log/slog"
 	url2 "net/url"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var supportedProtocols = map[string]struct{}{
@@ -44,38 +50,78 @@ type Configuration struct {
 	// The NodePreference to use when connecting.
 	NodePreference NodePreference
 
+	// Name of the connection, sent to the server with every call.
+	ConnectionName string
+
 	// The username to use for authenticating against the KurrentDB instance.
 	Username string
 
 	// The password to use for authenticating against the KurrentDB instance.
 	Password string
 
+	// Supplies the credentials of every call in place of Username and Password, so that rotated credentials take effect
+	// without recreating the Client. Credentials passed in an operation's options still take precedence.
+	CredentialsProvider CredentialsProvider
+
+	// Supplies a token, such as a JWT or an OAuth2 access token, sent with every call in place of CredentialsProvider,
+	// Username and Password. Credentials passed in an operation's options still take precedence.
+	TokenSource TokenSource
+
+	// What happens to credentials when TLS is disabled, as they would be sent in clear text. Applies to the
+	// credentials of the configuration, of Client.WithCredentials and of operations' options alike.
+	InsecureCredentials InsecureCredentialsPolicy // Defaults to InsecureCredentialsWarn.
+
 	// RootCAs defines the set of root certificate authorities
 	// that clients use when verifying server certificates.
 	// If RootCAs is nil, TLS uses the host's root CA set.
 	RootCAs *x509.CertPool // Defaults to nil.
 
+	// Where RootCAs was parsed from by ParseConnectionString, so that ConnectionString can emit it again.
+	rootCAsFile   string
+	rootCAsPEM    string
+	parsedRootCAs *x509.CertPool
+
 	// The path to the file containing the X.509 user certificate in PEM format.
 	UserCertFile string
 
 	// The path to the file containing the user certificate’s matching private key in PEM format
 	UserKeyFile string
 
+	// Supplies the X.509 user certificate on every TLS handshake in place of UserCertFile and UserKeyFile. When only
+	// those are set, a FileCertificateProvider loads them again whenever they change.
+	CertificateProvider CertificateProvider
+
 	// Allows to skip certificate validation.
 	SkipCertificateVerification bool // Defaults to false.
 
 	// The maximum number of times to attempt end point discovery.
 	MaxDiscoverAttempts int // Defaults to 10.
 
-	// The polling interval (in milliseconds) used to discover the end point.
+	// The initial wait (in milliseconds) between discovery attempts. The wait doubles after every failed attempt, up to
+	// 5 seconds, and is randomized to spread reconnecting clients.
 	DiscoveryInterval int // Defaults to 100 milliseconds.
 
 	// The amount of time (in seconds) after which an attempt to discover gossip will fail.
 	GossipTimeout int // Defaults to 5 seconds.
 
-	// Specifies if DNS discovery should be used.
+	// Specifies if DNS discovery should be used. The host is looked up as a _kurrentdb._tcp SRV record, or as is when it
+	// starts with an underscore, falling back to its A and AAAA records. Records are resolved again on every discovery
+	// attempt.
 	DnsDiscover bool // Defaults to false.
 
+	// Resolves the DNS records used by DnsDiscover. Defaults to net.DefaultResolver.
+	Resolver Resolver
+
+	// The amount of time (in milliseconds) operations fail fast with ErrorCodeCircuitOpen after discovery failed,
+	// before the next operation tries discovering a node again. Zero, or -1, makes every operation try again straight
+	// away.
+	CircuitBreakerCooldown time.Duration // Defaults to 5 seconds.
+
+	// The interval (in milliseconds) at which gossip is read again to move new operations to a better node according to
+	// NodePreference. Operations in flight on the previous node complete there. Only applies when connecting to a
+	// cluster. Zero disables.
+	GossipRefreshInterval time.Duration // Defaults to 0.
+
 	// The amount of time (in milliseconds) to wait after which a keepalive ping is sent on the transport.
 	// If set below 10s, a minimum value of 10s will be used instead. Use -1 to disable. Use -1 to disable.
 	KeepAliveInterval time.Duration // Defaults to 10 seconds.
@@ -87,38 +133,122 @@ type Configuration struct {
 	// DeadlineExceeded. Defaults to 10 seconds.
 	DefaultDeadline *time.Duration
 
-	// Logging abstraction used by the client.
+	// Retries appends, deletes and persistent subscription management calls after a transient failure, such as the
+	// leader of the cluster changing. Nil disables retries.
+	RetryPolicy *RetryPolicy
+
+	// Maps Go types to event types in AppendEvents. Defaults to DefaultSerializer.
+	Serializer *Serializer
+
+	// Records a span around every operation and propagates the trace context into the metadata of appended events.
+	// Nil disables tracing.
+	TracerProvider trace.TracerProvider
+
+	// Records append latency, subscription lag and reconnect counts. Nil disables metrics.
+	MeterProvider metric.MeterProvider
+
+	// Logging abstraction used by the client. Ignored when StructuredLogger is set.
 	Logger LoggingFunc
+
+	// Minimum level of the records passed to Logger or StructuredLogger. Defaults to every level.
+	LogLevel LogLevel
+
+	// Structured logger used by the client. Records carry the connection id, endpoint, attempt number, subscription id
+	// and error code when relevant. Takes precedence over Logger.
+	StructuredLogger *slog.Logger
 }
 
 func (conf *Configuration) applyLogger(level LogLevel, format string, args ...interface{}) {
+	if conf.StructuredLogger != nil {
+		conf.StructuredLogger.Log(context.Background(), slogLevelOf(level), fmt.Sprintf(format, args...))
+		return
+	}
+
 	if conf.Logger != nil {
-		conf.Logger(level, format, args)
+		conf.Logger(level, format, args...)
 	}
 }
 
+// Validate checks that the configuration can be used to create a client. Every problem found is reported in the
+// returned error, joined with errors.Join.
 func (conf *Configuration) Validate() error {
+	var errs []error
+
+	if conf.Address == "" && len(conf.GossipSeeds) == 0 {
+		errs = append(errs, fmt.Errorf("either an address or gossip seeds must be provided"))
+	}
+
 	if (conf.UserCertFile == "") != (conf.UserKeyFile == "") {
-		return fmt.Errorf("both userCertFile and userKeyFile must be provided")
+		errs = append(errs, fmt.Errorf("both userCertFile and userKeyFile must be provided"))
 	}
 
-	return nil
+	switch conf.NodePreference {
+	case "", NodePreferenceLeader, NodePreferenceFollower, NodePreferenceReadOnlyReplica, NodePreferenceRandom:
+	default:
+		errs = append(errs, fmt.Errorf("invalid NodePreference '%s'", conf.NodePreference))
+	}
+
+	switch conf.LogLevel {
+	case "", LogDebug, LogInfo, LogWarn, LogError:
+	default:
+		errs = append(errs, fmt.Errorf("invalid LogLevel '%s'", conf.LogLevel))
+	}
+
+	switch conf.InsecureCredentials {
+	case "", InsecureCredentialsWarn, InsecureCredentialsAllow:
+	case InsecureCredentialsError:
+		if conf.DisableTLS && (conf.Username != "" || conf.CredentialsProvider != nil || conf.TokenSource != nil) {
+			errs = append(errs, fmt.Errorf("credentials can't be sent without TLS when insecureCredentials is error"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid InsecureCredentials '%s'", conf.InsecureCredentials))
+	}
+
+	if conf.MaxDiscoverAttempts < 0 {
+		errs = append(errs, fmt.Errorf("maxDiscoverAttempts must not be negative"))
+	}
+
+	if conf.DiscoveryInterval < 0 {
+		errs = append(errs, fmt.Errorf("discoveryInterval must not be negative"))
+	}
+
+	if conf.GossipTimeout < 0 {
+		errs = append(errs, fmt.Errorf("gossipTimeout must not be negative"))
+	}
+
+	if conf.DefaultDeadline != nil && *conf.DefaultDeadline <= 0 {
+		errs = append(errs, fmt.Errorf("defaultDeadline must be positive"))
+	}
+
+	return errors.Join(errs...)
 }
 
 func initConfiguration() *Configuration {
 	return &Configuration{
-		DiscoveryInterval:   100,
-		GossipTimeout:       5,
-		MaxDiscoverAttempts: 10,
-		KeepAliveInterval:   10 * time.Second,
-		KeepAliveTimeout:    10 * time.Second,
-		NodePreference:      NodePreferenceLeader,
-		Logger:              ConsoleLogging(),
+		DiscoveryInterval:      100,
+		GossipTimeout:          5,
+		MaxDiscoverAttempts:    10,
+		CircuitBreakerCooldown: 5 * time.Second,
+		KeepAliveInterval:      10 * time.Second,
+		KeepAliveTimeout:       10 * time.Second,
+		NodePreference:         NodePreferenceLeader,
+		Logger:                 ConsoleLogging(),
 	}
 }
 
-// ParseConnectionString creates a Configuration based on an EventStoreDb connection string.
+// ParseConnectionString creates a Configuration based on an EventStoreDb connection string. Unknown settings are
+// logged and ignored.
 func ParseConnectionString(connectionString string) (*Configuration, error) {
+	return parseConnectionString(connectionString, false)
+}
+
+// ParseConnectionStringStrict creates a Configuration based on a connection string like ParseConnectionString, but
+// fails on unknown settings, for instance a misspelled key.
+func ParseConnectionStringStrict(connectionString string) (*Configuration, error) {
+	return parseConnectionString(connectionString, true)
+}
+
+func parseConnectionString(connectionString string, strict bool) (*Configuration, error) {
 	url, err := url2.Parse(connectionString)
 
 	if err != nil {
@@ -169,10 +299,10 @@ func ParseConnectionString(connectionString string) (*Configuration, error) {
 		}
 
 		config.GossipSeeds = endpoints
-		return parseFromUrl(config, url)
+		return parseFromUrl(config, url, strict)
 	}
 
-	return parseFromUrl(initConfiguration(), url)
+	return parseFromUrl(initConfiguration(), url, strict)
 }
 
 func parseEndpoint(host string) (*EndPoint, error) {
@@ -210,7 +340,7 @@ func parseEndpoint(host string) (*EndPoint, error) {
 	return endpoint, nil
 }
 
-func parseFromUrl(config *Configuration, url *url2.URL) (*Configuration, error) {
+func parseFromUrl(config *Configuration, url *url2.URL, strict bool) (*Configuration, error) {
 	if _, exists := supportedProtocols[url.Scheme]; !exists {
 		return nil, fmt.Errorf("an invalid scheme is specified")
 	}
@@ -264,7 +394,7 @@ func parseFromUrl(config *Configuration, url *url2.URL) (*Configuration, error)
 			continue
 		}
 
-		err := parseSetting(key, values[0], config)
+		err := parseSetting(key, values[0], config, strict)
 		if err != nil {
 			return nil, err
 		}
@@ -273,7 +403,7 @@ func parseFromUrl(config *Configuration, url *url2.URL) (*Configuration, error)
 	return config, nil
 }
 
-func parseSetting(k, v string, config *Configuration) error {
+func parseSetting(k, v string, config *Configuration, strict bool) error {
 	normalizedKey := strings.ToLower(k)
 	switch normalizedKey {
 	case "discoveryinterval":
@@ -296,6 +426,16 @@ func parseSetting(k, v string, config *Configuration) error {
 		if err != nil {
 			return err
 		}
+	case "circuitbreakercooldown":
+		err := parseKeepAliveSetting(k, v, &config.CircuitBreakerCooldown)
+		if err != nil {
+			return err
+		}
+	case "gossiprefreshinterval":
+		err := parseDurationAsMs(k, v, &config.GossipRefreshInterval)
+		if err != nil {
+			return err
+		}
 	case "keepaliveinterval":
 		err := parseKeepAliveSetting(k, v, &config.KeepAliveInterval)
 		if err != nil {
@@ -320,6 +460,14 @@ func parseSetting(k, v string, config *Configuration) error {
 		if err != nil {
 			return err
 		}
+	case "tlscapem":
+		err := parseCertificatePEM([]byte(v), config)
+		if err != nil {
+			return err
+		}
+
+		config.rootCAsFile = ""
+		config.rootCAsPEM = v
 	case "usercertfile":
 		config.UserCertFile = resolvePath(v)
 	case "userkeyfile":
@@ -333,9 +481,40 @@ func parseSetting(k, v string, config *Configuration) error {
 		config.DefaultDeadline = new(time.Duration)
 		err := parseDurationAsMs(k, v, config.DefaultDeadline)
 		if err != nil {
-			return nil
+			return err
+		}
+	case "connectionname":
+		config.ConnectionName = v
+	case "loglevel":
+		err := parseLogLevel(v, config)
+		if err != nil {
+			return err
+		}
+	case "insecurecredentials":
+		err := parseInsecureCredentialsPolicy(v, config)
+		if err != nil {
+			return err
+		}
+	case "retrymaxattempts":
+		err := parseIntSetting(k, v, &config.retryPolicy().MaxAttempts)
+		if err != nil {
+			return err
+		}
+	case "retryinitialbackoff":
+		err := parseDurationAsMs(k, v, &config.retryPolicy().InitialBackoff)
+		if err != nil {
+			return err
+		}
+	case "retrymaxbackoff":
+		err := parseDurationAsMs(k, v, &config.retryPolicy().MaxBackoff)
+		if err != nil {
+			return err
 		}
 	default:
+		if strict {
+			return fmt.Errorf("unknown setting '%s'", k)
+		}
+
 		config.applyLogger(LogWarn, "Unknown setting: %s", k)
 	}
 
@@ -348,16 +527,59 @@ func parseCertificateFile(certFile string, config *Configuration) error {
 		return err
 	}
 
+	if err := parseCertificatePEM(b, config); err != nil {
+		return fmt.Errorf("failed to append certificate file")
+	}
+
+	config.rootCAsFile = certFile
+	config.rootCAsPEM = ""
+
+	return nil
+}
+
+func parseCertificatePEM(b []byte, config *Configuration) error {
 	cp := x509.NewCertPool()
 	if !cp.AppendCertsFromPEM(b) {
-		return fmt.Errorf("failed to append certificate file")
+		return fmt.Errorf("failed to append PEM certificates")
 	}
 
 	config.RootCAs = cp
+	config.parsedRootCAs = cp
+
+	return nil
+}
+
+func parseLogLevel(v string, config *Configuration) error {
+	switch level := strings.ToLower(v); level {
+	case LogDebug, LogInfo, LogWarn, LogError:
+		config.LogLevel = level
+	default:
+		return fmt.Errorf("Invalid LogLevel: '%s'", v)
+	}
+
+	return nil
+}
+
+func parseInsecureCredentialsPolicy(v string, config *Configuration) error {
+	switch policy := strings.ToLower(v); policy {
+	case InsecureCredentialsWarn, InsecureCredentialsError, InsecureCredentialsAllow:
+		config.InsecureCredentials = policy
+	default:
+		return fmt.Errorf("Invalid InsecureCredentials: '%s'", v)
+	}
 
 	return nil
 }
 
+// retryPolicy returns the configuration's RetryPolicy, creating an empty one if needed.
+func (conf *Configuration) retryPolicy() *RetryPolicy {
+	if conf.RetryPolicy == nil {
+		conf.RetryPolicy = &RetryPolicy{}
+	}
+
+	return conf.RetryPolicy
+}
+
 func parseBoolSetting(k, v string, b *bool, inverse bool) error {
 	var err error
 	*b, err = strconv.ParseBool(strings.ToLower(v))
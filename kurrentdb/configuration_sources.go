@@ -0,0 +1,230 @@
+package kurrentdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultEnvPrefix is the prefix of the environment variables read by ConfigurationFromEnv when none is given. It
+// leaves out the KURRENTDB_DOCKER_* variables used to run the test server.
+const DefaultEnvPrefix = "KURRENTDB_CLIENT_"
+
+// ConfigurationFromEnv creates a Configuration from the environment variables starting with prefix, DefaultEnvPrefix
+// if empty. The rest of a variable's name is a connection string setting, in any case and with underscores between
+// words, for instance KURRENTDB_CLIENT_NODE_PREFERENCE or KURRENTDB_CLIENT_KEEP_ALIVE_INTERVAL. On top of the
+// connection string settings, the following variables are read:
+//
+//   - CONNECTION_STRING, a connection string the other variables override.
+//   - ADDRESS, the host:port of a single node.
+//   - GOSSIP_SEEDS, comma-separated host:port gossip seeds.
+//   - DNS_DISCOVER, true to discover the cluster through DNS.
+//   - USERNAME and PASSWORD.
+//
+// Every invalid or unknown variable is reported, along with the Configuration.Validate errors, in a single error.
+func ConfigurationFromEnv(prefix string) (*Configuration, error) {
+	if prefix == "" {
+		prefix = DefaultEnvPrefix
+	}
+
+	settings := make(map[string]string)
+	for _, variable := range os.Environ() {
+		name, value, _ := strings.Cut(variable, "=")
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		settings[name] = value
+	}
+
+	return configurationFromSettings(settings, func(name string) string {
+		return strings.TrimPrefix(name, prefix)
+	})
+}
+
+// ConfigurationFromFile creates a Configuration from a JSON, YAML or TOML file, picked by the .json, .yaml, .yml or
+// .toml extension. The file holds a flat object whose keys are the ones ConfigurationFromEnv reads, in camel case,
+// for instance nodePreference or gossipSeeds, which can be a list. Relative tlsCaFile, userCertFile and userKeyFile
+// paths are resolved from the file's directory.
+//
+// Every invalid or unknown key is reported, along with the Configuration.Validate errors, in a single error.
+func ConfigurationFromFile(path string) (*Configuration, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	document := make(map[string]any)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(content, &document)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &document)
+	case ".toml":
+		_, err = toml.Decode(string(content), &document)
+	default:
+		return nil, fmt.Errorf("unsupported configuration file extension '%s', expected .json, .yaml, .yml or .toml", ext)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse configuration file %s: %w", path, err)
+	}
+
+	var errs []error
+	settings := make(map[string]string)
+	for key, value := range document {
+		text, err := settingText(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			continue
+		}
+
+		switch normalizeSettingName(key) {
+		case "tlscafile", "usercertfile", "userkeyfile":
+			if text != "" && !filepath.IsAbs(text) {
+				text = filepath.Join(filepath.Dir(path), text)
+			}
+		}
+
+		settings[key] = text
+	}
+
+	config, err := configurationFromSettings(settings, func(name string) string { return name })
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return config, nil
+}
+
+// configurationFromSettings applies named settings on top of the connection string setting, if any. The setting
+// names are reported as is in errors, while nameOf returns the part of a name that identifies the setting.
+func configurationFromSettings(settings map[string]string, nameOf func(string) string) (*Configuration, error) {
+	var errs []error
+	names := make([]string, 0, len(settings))
+	var connectionString string
+
+	for name, value := range settings {
+		if normalizeSettingName(nameOf(name)) == "connectionstring" {
+			connectionString = value
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	// Settings are applied in a stable order so that errors are too.
+	sort.Strings(names)
+
+	config := initConfiguration()
+	if connectionString != "" {
+		parsed, err := ParseConnectionStringStrict(connectionString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid connection string: %w", err)
+		}
+
+		config = parsed
+	}
+
+	for _, name := range names {
+		if err := applySetting(normalizeSettingName(nameOf(name)), settings[name], config); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return config, nil
+}
+
+// applySetting applies a setting read from the environment or a file, which is either a connection string setting or
+// one of the parts of a connection string outside its query.
+func applySetting(key, value string, config *Configuration) error {
+	switch key {
+	case "address":
+		endpoint, err := parseEndpoint(value)
+		if err != nil {
+			return err
+		}
+
+		config.Address = endpoint.String()
+		config.GossipSeeds = nil
+	case "gossipseeds":
+		seeds := make([]*EndPoint, 0)
+		for _, seed := range strings.Split(value, ",") {
+			endpoint, err := parseEndpoint(strings.TrimSpace(seed))
+			if err != nil {
+				return err
+			}
+
+			seeds = append(seeds, endpoint)
+		}
+
+		config.GossipSeeds = seeds
+	case "dnsdiscover":
+		return parseBoolSetting(key, value, &config.DnsDiscover, false)
+	case "username":
+		config.Username = value
+	case "password":
+		config.Password = value
+	default:
+		return parseSetting(key, value, config, true)
+	}
+
+	return nil
+}
+
+// normalizeSettingName turns environment variable and file key names into the lower case names parseSetting expects.
+func normalizeSettingName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+// settingText turns a decoded file value into the text a connection string would carry. Lists are joined with commas.
+func settingText(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			text, err := settingText(item)
+			if err != nil {
+				return "", err
+			}
+
+			items = append(items, text)
+		}
+
+		return strings.Join(items, ","), nil
+	case nil:
+		return "", nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", value)
+	}
+}
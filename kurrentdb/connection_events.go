@@ -0,0 +1,144 @@
+package kurrentdb
+
+import (
+	"context"
+	"sync"
+)
+
+// ConnectionEvent is published when the connection of a client changes state. Only one field is set.
+type ConnectionEvent struct {
+	Discovering   *ConnectionDiscovering
+	Connected     *ConnectionConnected
+	LeaderChanged *ConnectionLeaderChanged
+	Disconnected  *ConnectionDisconnected
+	Closed        *ConnectionClosed
+}
+
+// ConnectionDiscovering is published when the client starts looking for a node to connect to.
+type ConnectionDiscovering struct{}
+
+// ConnectionConnected is published when the client is connected to a node.
+type ConnectionConnected struct {
+	// Endpoint of the node.
+	Endpoint EndPoint
+	// Version of the node. Zero if the node doesn't expose its supported features.
+	ServerVersion ServerVersion
+	// State of the node, NodeStateUnknown if it was reached without reading gossip.
+	NodeState NodeState
+}
+
+// ConnectionLeaderChanged is published when the client learns that the cluster has a new leader, either because a
+// node answered that it is not the leader anymore or because discovery picked another leader.
+type ConnectionLeaderChanged struct {
+	// Endpoint of the previous leader, nil if the client wasn't connected to a leader.
+	Previous *EndPoint
+	// Endpoint of the new leader.
+	Leader EndPoint
+}
+
+// ConnectionDisconnected is published when the client loses its connection or fails to discover a node.
+type ConnectionDisconnected struct {
+	// Error that caused the disconnection.
+	Error error
+}
+
+// ConnectionClosed is published once, when the client is closed.
+type ConnectionClosed struct{}
+
+// connectionEventsBufferSize is the number of events a watcher can lag behind before events are dropped.
+const connectionEventsBufferSize = 32
+
+// connectionEvents fans connection events out to the watchers of a client. Publishing never blocks the connection
+// state machine: events are dropped for a watcher whose buffer is full.
+type connectionEvents struct {
+	mu       sync.Mutex
+	watchers map[chan *ConnectionEvent]struct{}
+	last     *ConnectionEvent
+	closed   bool
+	done     chan struct{}
+}
+
+func newConnectionEvents() *connectionEvents {
+	return &connectionEvents{
+		watchers: make(map[chan *ConnectionEvent]struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+func (events *connectionEvents) publish(event *ConnectionEvent) {
+	events.mu.Lock()
+	defer events.mu.Unlock()
+
+	if events.closed {
+		return
+	}
+
+	// Leader changes are followed by a connection event, only the latter describes the connection state.
+	if event.LeaderChanged == nil {
+		events.last = event
+	}
+
+	for watcher := range events.watchers {
+		select {
+		case watcher <- event:
+		default:
+		}
+	}
+
+	if event.Closed != nil {
+		events.closed = true
+		for watcher := range events.watchers {
+			close(watcher)
+		}
+
+		events.watchers = nil
+		close(events.done)
+	}
+}
+
+// watch returns a channel receiving the latest state event then every subsequent one, until ctx is done or the
+// connection is closed.
+func (events *connectionEvents) watch(ctx context.Context) <-chan *ConnectionEvent {
+	watcher := make(chan *ConnectionEvent, connectionEventsBufferSize)
+
+	events.mu.Lock()
+	defer events.mu.Unlock()
+
+	if events.last != nil {
+		watcher <- events.last
+	}
+
+	if events.closed {
+		close(watcher)
+		return watcher
+	}
+
+	events.watchers[watcher] = struct{}{}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-events.done:
+			return
+		}
+
+		events.mu.Lock()
+		defer events.mu.Unlock()
+
+		if _, ok := events.watchers[watcher]; ok {
+			delete(events.watchers, watcher)
+			close(watcher)
+		}
+	}()
+
+	return watcher
+}
+
+// WatchConnection returns a channel publishing the state changes of the client's connection. The channel first
+// receives the event describing the current state, if any, and is closed after the ConnectionClosed event or when ctx
+// is done. Events are dropped if the receiver falls more than 32 events behind.
+//
+// The client connects lazily, no event is published before the first operation.
+func (client *Client) WatchConnection(ctx context.Context) <-chan *ConnectionEvent {
+	return client.grpcClient.events.watch(ctx)
+}
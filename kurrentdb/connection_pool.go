@@ -0,0 +1,211 @@
+package kurrentdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	gossipApi "github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/gossip"
+	"github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/shared"
+)
+
+// pooledConnection is a connection kept alongside the main one, to the node picked by a NodePreference other than the
+// configuration's.
+type pooledConnection struct {
+	correlation uuid.UUID
+	connection  *grpc.ClientConn
+	serverInfo  *serverInfo
+	node        *discoveredNode
+	// Tells if the main connection already targets the node, in which case the pooled connection is that one.
+	shared bool
+}
+
+func (pooled *pooledConnection) handle() connectionHandle {
+	return newConnectionHandle(pooled.correlation, pooled.serverInfo, pooled.connection)
+}
+
+func (pooled *pooledConnection) close() {
+	if pooled.shared {
+		return
+	}
+
+	if pooled.node.drain != nil {
+		pooled.node.drain.retire()
+		return
+	}
+
+	_ = pooled.connection.Close()
+}
+
+// preferredConnection is the outcome of connecting to the node picked by a NodePreference, posted back to the
+// connection state machine.
+type preferredConnection struct {
+	// Correlation of the main connection gossip was read through.
+	correlation uuid.UUID
+	preference  NodePreference
+	pooled      *pooledConnection
+	err         error
+}
+
+// handleFor answers an operation asking for the node picked by a NodePreference, connecting to it if needed. The main
+// connection is used when the preference is empty or the configuration's, when the client isn't connected to a
+// cluster, and when the cluster has no better node to offer. Connecting runs in the background and posts its outcome
+// to results, so that operations on other preferences aren't held up; the operations for that preference wait for it.
+func (state *connectionState) handleFor(evt getConnection, results chan<- *preferredConnection, done <-chan struct{}) {
+	preference := evt.preference
+
+	if preference == "" || preference == state.config.NodePreference || !isClusterConnection(&state.config) || state.node == nil {
+		evt.reply(newConnectionHandle(state.correlation, state.serverInfo, state.connection))
+		return
+	}
+
+	if pooled, ok := state.pool[preference]; ok {
+		evt.reply(pooled.handle())
+		return
+	}
+
+	if waiting, ok := state.dialing[preference]; ok {
+		state.dialing[preference] = append(waiting, evt)
+		return
+	}
+
+	if state.dialing == nil {
+		state.dialing = make(map[NodePreference][]getConnection)
+	}
+
+	state.dialing[preference] = []getConnection{evt}
+
+	go func(conf Configuration, correlation uuid.UUID, conn *grpc.ClientConn, current EndPoint) {
+		pooled, err := connectPreferred(&conf, conn, current, preference)
+		result := &preferredConnection{correlation: correlation, preference: preference, pooled: pooled, err: err}
+
+		select {
+		case results <- result:
+		case <-done:
+			if pooled != nil {
+				_ = pooled.connection.Close()
+			}
+		}
+	}(state.config, state.correlation, state.connection, state.node.endpoint)
+}
+
+// connectedPreferred pools the outcome of connecting to a preferred node and answers the operations waiting for it,
+// falling back to the main connection on error. If the main connection changed in the meantime the outcome is stale:
+// it is dropped and the waiting operations are returned, to be answered again.
+func (state *connectionState) connectedPreferred(result *preferredConnection, logger *logger) []getConnection {
+	waiting := state.dialing[result.preference]
+	delete(state.dialing, result.preference)
+
+	if result.correlation != state.correlation {
+		if result.pooled != nil {
+			_ = result.pooled.connection.Close()
+		}
+
+		return waiting
+	}
+
+	handle := newConnectionHandle(state.correlation, state.serverInfo, state.connection)
+
+	if result.err != nil {
+		logger.withError(result.err).warn("error when connecting to the preferred node, using the main connection",
+			LogKeyConnectionId, state.correlation, "preference", result.preference.String())
+	} else {
+		pooled := result.pooled
+		if pooled == nil {
+			pooled = &pooledConnection{
+				correlation: state.correlation,
+				connection:  state.connection,
+				serverInfo:  state.serverInfo,
+				node:        state.node,
+				shared:      true,
+			}
+		} else {
+			logger.info("connected to preferred node", LogKeyConnectionId, pooled.correlation,
+				LogKeyEndpoint, pooled.node.endpoint.String(), "preference", result.preference.String())
+		}
+
+		if state.pool == nil {
+			state.pool = make(map[NodePreference]*pooledConnection)
+		}
+
+		state.pool[result.preference] = pooled
+		handle = pooled.handle()
+	}
+
+	for _, evt := range waiting {
+		evt.reply(handle)
+	}
+
+	return nil
+}
+
+// dropPooled forgets the pooled connection a reconnection request is about, so the next operation connects to the
+// preferred node again. It tells if the request was about a pooled connection.
+func (state *connectionState) dropPooled(correlation uuid.UUID) bool {
+	for preference, pooled := range state.pool {
+		if pooled.correlation == correlation && !pooled.shared {
+			pooled.close()
+			delete(state.pool, preference)
+			return true
+		}
+	}
+
+	return false
+}
+
+// resetPool forgets every pooled connection, letting their in-flight RPCs complete. It is called whenever the main
+// connection changes, as the cluster may have changed shape.
+func (state *connectionState) resetPool() {
+	for _, pooled := range state.pool {
+		pooled.close()
+	}
+
+	state.pool = nil
+}
+
+// connectPreferred reads gossip through an existing connection and connects to the best candidate for a preference.
+// It returns nil if the node the connection targets ranks as well as the best candidate.
+func connectPreferred(conf *Configuration, conn *grpc.ClientConn, current EndPoint, preference NodePreference) (*pooledConnection, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(conf.GossipTimeout)*time.Second)
+	info, err := gossipApi.NewGossipClient(conn).Read(ctx, &shared.Empty{})
+	cancel()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gossip: %w", err)
+	}
+
+	info.Members = shuffleMembers(info.Members)
+	selected, err := pickBestCandidate(info, preference)
+	if err != nil {
+		return nil, err
+	}
+
+	if isPreferredMember(info, selected, current, preference) {
+		return nil, nil
+	}
+
+	selectedAddress := fmt.Sprintf("%s:%d", selected.GetHttpEndPoint().GetAddress(), selected.GetHttpEndPoint().GetPort())
+	connection, drain, err := createGrpcConnection(conf, selectedAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	serverInfo, err := getSupportedMethods(context.Background(), conf, connection)
+	if err != nil {
+		_ = connection.Close()
+		return nil, fmt.Errorf("failed to read server features from %s: %w", selectedAddress, err)
+	}
+
+	node := newDiscoveredNode(selectedAddress, nodeStateFromProto(selected.State))
+	node.drain = drain
+
+	return &pooledConnection{
+		correlation: uuid.New(),
+		connection:  connection,
+		serverInfo:  serverInfo,
+		node:        node,
+	}, nil
+}
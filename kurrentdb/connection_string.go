@@ -0,0 +1,177 @@
+package kurrentdb
+
+import (
+	url2 "net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redactedSecret replaces the password in RedactedConnectionString.
+const redactedSecret = "xxxxx"
+
+// ConnectionString returns a canonical connection string that ParseConnectionString turns back into an equivalent
+// Configuration. Settings left to their default value are omitted.
+//
+// Fields that have no connection string setting are left out: CredentialsProvider, TokenSource, CertificateProvider,
+// Resolver, RetryPolicy.RetryableCodes, Serializer, TracerProvider, MeterProvider, Logger, StructuredLogger, and RootCAs
+// unless it was parsed from tlsCaFile or tlsCaPem.
+func (conf *Configuration) ConnectionString() string {
+	return conf.connectionString(false)
+}
+
+// RedactedConnectionString returns ConnectionString with the password masked, fit for logs and error messages.
+func (conf *Configuration) RedactedConnectionString() string {
+	return conf.connectionString(true)
+}
+
+func (conf *Configuration) connectionString(redact bool) string {
+	var builder strings.Builder
+
+	builder.WriteString("kurrentdb")
+	if conf.DnsDiscover {
+		builder.WriteString("+discover")
+	}
+
+	builder.WriteString("://")
+
+	if conf.Username != "" {
+		password := conf.Password
+		if redact && password != "" {
+			password = redactedSecret
+		}
+
+		builder.WriteString(url2.UserPassword(conf.Username, password).String())
+		builder.WriteString("@")
+	}
+
+	if len(conf.GossipSeeds) > 0 {
+		for i, seed := range conf.GossipSeeds {
+			if i > 0 {
+				builder.WriteString(",")
+			}
+
+			builder.WriteString(seed.String())
+		}
+	} else {
+		builder.WriteString(conf.Address)
+	}
+
+	for i, setting := range conf.settings() {
+		if i == 0 {
+			builder.WriteString("?")
+		} else {
+			builder.WriteString("&")
+		}
+
+		builder.WriteString(setting[0])
+		builder.WriteString("=")
+		builder.WriteString(url2.QueryEscape(setting[1]))
+	}
+
+	return builder.String()
+}
+
+// settings lists the connection string settings whose value differs from the default, in a stable order.
+func (conf *Configuration) settings() [][2]string {
+	defaults := initConfiguration()
+	var settings [][2]string
+
+	add := func(key, value string) {
+		settings = append(settings, [2]string{key, value})
+	}
+
+	if conf.DisableTLS {
+		add("tls", "false")
+	}
+
+	if conf.SkipCertificateVerification {
+		add("tlsVerifyCert", "false")
+	}
+
+	if conf.RootCAs != nil && conf.RootCAs == conf.parsedRootCAs {
+		if conf.rootCAsFile != "" {
+			add("tlsCaFile", conf.rootCAsFile)
+		} else if conf.rootCAsPEM != "" {
+			add("tlsCaPem", conf.rootCAsPEM)
+		}
+	}
+
+	if conf.UserCertFile != "" {
+		add("userCertFile", conf.UserCertFile)
+	}
+
+	if conf.UserKeyFile != "" {
+		add("userKeyFile", conf.UserKeyFile)
+	}
+
+	if conf.ConnectionName != "" {
+		add("connectionName", conf.ConnectionName)
+	}
+
+	if conf.NodePreference != "" && conf.NodePreference != defaults.NodePreference {
+		preference := conf.NodePreference.String()
+		add("nodePreference", strings.ToLower(preference[:1])+preference[1:])
+	}
+
+	if conf.MaxDiscoverAttempts != defaults.MaxDiscoverAttempts {
+		add("maxDiscoverAttempts", strconv.Itoa(conf.MaxDiscoverAttempts))
+	}
+
+	if conf.DiscoveryInterval != defaults.DiscoveryInterval {
+		add("discoveryInterval", strconv.Itoa(conf.DiscoveryInterval))
+	}
+
+	if conf.GossipTimeout != defaults.GossipTimeout {
+		add("gossipTimeout", strconv.Itoa(conf.GossipTimeout))
+	}
+
+	if conf.CircuitBreakerCooldown != defaults.CircuitBreakerCooldown {
+		add("circuitBreakerCooldown", formatMs(conf.CircuitBreakerCooldown))
+	}
+
+	if conf.GossipRefreshInterval > 0 {
+		add("gossipRefreshInterval", formatMs(conf.GossipRefreshInterval))
+	}
+
+	if conf.KeepAliveInterval != defaults.KeepAliveInterval {
+		add("keepAliveInterval", formatMs(conf.KeepAliveInterval))
+	}
+
+	if conf.KeepAliveTimeout != defaults.KeepAliveTimeout {
+		add("keepAliveTimeout", formatMs(conf.KeepAliveTimeout))
+	}
+
+	if conf.DefaultDeadline != nil {
+		add("defaultDeadline", formatMs(*conf.DefaultDeadline))
+	}
+
+	if conf.RetryPolicy != nil {
+		policy := *conf.RetryPolicy
+		policy.setDefaults()
+
+		add("retryMaxAttempts", strconv.Itoa(policy.MaxAttempts))
+		add("retryInitialBackoff", formatMs(policy.InitialBackoff))
+		add("retryMaxBackoff", formatMs(policy.MaxBackoff))
+	}
+
+	if conf.LogLevel != "" {
+		add("logLevel", conf.LogLevel)
+	}
+
+	if conf.InsecureCredentials != "" && conf.InsecureCredentials != InsecureCredentialsWarn {
+		add("insecureCredentials", conf.InsecureCredentials)
+	}
+
+	return settings
+}
+
+// formatMs formats a duration as the milliseconds connection string settings expect, -1 standing for any negative
+// duration.
+func formatMs(d time.Duration) string {
+	if d < 0 {
+		return "-1"
+	}
+
+	return strconv.FormatInt(d.Milliseconds(), 10)
+}
@@ -1,9 +1,59 @@
 package kurrentdb
 
-// Credentials holds a login and a password for authenticated requests.
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Credentials holds a login and a password, or a token, for authenticated requests.
 type Credentials struct {
 	// User's login.
 	Login string
 	// User's password.
 	Password string
+	// Supplies a token sent in place of Login and Password when set.
+	Token TokenSource
+}
+
+// authorization returns the value of the authorization header carrying the credentials. A TokenSource supplying no
+// access token fails the call, which is reported as unauthenticated.
+func (creds *Credentials) authorization(ctx context.Context) (string, error) {
+	if creds.Token == nil {
+		return basicAuthorization(creds.Login, creds.Password), nil
+	}
+
+	token, err := creds.Token.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get token: %w", err)
+	}
+
+	if token == nil || token.AccessToken == "" {
+		return "", status.Error(codes.Unauthenticated, "failed to get token: the token source returned no access token")
+	}
+
+	return token.authorization(), nil
+}
+
+// CredentialsProvider supplies the credentials of a call when it is made, so that rotated credentials take effect
+// without recreating the Client. Returning nil credentials sends the call unauthenticated.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (*Credentials, error)
+}
+
+// CertificateProvider supplies the X.509 user certificate presented on every TLS handshake, so that a rotated
+// certificate is used by the next connection without recreating the Client.
+type CertificateProvider interface {
+	Certificate() (*tls.Certificate, error)
+}
+
+// CredentialsProviderFunc turns a function into a CredentialsProvider.
+type CredentialsProviderFunc func(ctx context.Context) (*Credentials, error)
+
+// Credentials calls f.
+func (f CredentialsProviderFunc) Credentials(ctx context.Context) (*Credentials, error) {
+	return f(ctx)
 }
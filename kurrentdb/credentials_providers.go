@@ -0,0 +1,127 @@
+package kurrentdb
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileCredentialsProvider reads credentials from a login file and a password file, such as mounted secrets, and reads
+// them again whenever either file changes. A trailing newline is ignored.
+type FileCredentialsProvider struct {
+	mu          sync.Mutex
+	watch       fileWatch
+	credentials *Credentials
+}
+
+// NewFileCredentialsProvider creates a FileCredentialsProvider. The files are read by the first call.
+func NewFileCredentialsProvider(loginFile, passwordFile string) *FileCredentialsProvider {
+	return &FileCredentialsProvider{watch: fileWatch{paths: []string{loginFile, passwordFile}}}
+}
+
+// Credentials returns the credentials in the files. While the files are being rewritten and can't be read, the
+// credentials read last are returned.
+func (provider *FileCredentialsProvider) Credentials(_ context.Context) (*Credentials, error) {
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	stamps, changed, err := provider.watch.check()
+	if err == nil && !changed {
+		return provider.credentials, nil
+	}
+
+	if err == nil {
+		var login, password []byte
+		if login, err = os.ReadFile(provider.watch.paths[0]); err == nil {
+			if password, err = os.ReadFile(provider.watch.paths[1]); err == nil {
+				provider.credentials = &Credentials{
+					Login:    strings.TrimRight(string(login), "\r\n"),
+					Password: strings.TrimRight(string(password), "\r\n"),
+				}
+				provider.watch.stamps = stamps
+			}
+		}
+	}
+
+	if err != nil && provider.credentials == nil {
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	return provider.credentials, nil
+}
+
+// FileCertificateProvider loads an X.509 user certificate and its private key from PEM files, and loads them again
+// whenever either file changes. It is the CertificateProvider used for Configuration.UserCertFile and
+// Configuration.UserKeyFile.
+type FileCertificateProvider struct {
+	mu          sync.Mutex
+	watch       fileWatch
+	certificate *tls.Certificate
+}
+
+// NewFileCertificateProvider creates a FileCertificateProvider. The files are loaded by the first call.
+func NewFileCertificateProvider(certFile, keyFile string) *FileCertificateProvider {
+	return &FileCertificateProvider{watch: fileWatch{paths: []string{certFile, keyFile}}}
+}
+
+// Certificate returns the certificate in the files. While the files are being rewritten and don't hold a matching
+// certificate and key, the certificate loaded last is returned.
+func (provider *FileCertificateProvider) Certificate() (*tls.Certificate, error) {
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	stamps, changed, err := provider.watch.check()
+	if err == nil && !changed {
+		return provider.certificate, nil
+	}
+
+	if err == nil {
+		var certificate tls.Certificate
+		if certificate, err = tls.LoadX509KeyPair(provider.watch.paths[0], provider.watch.paths[1]); err == nil {
+			provider.certificate = &certificate
+			provider.watch.stamps = stamps
+		}
+	}
+
+	if err != nil && provider.certificate == nil {
+		return nil, err
+	}
+
+	return provider.certificate, nil
+}
+
+// fileWatch tells if any of a set of files changed since it was last loaded, comparing modification times and sizes.
+// Symbolic links are followed, so that swapping the target of a link, like Kubernetes does for secrets, is a change.
+type fileWatch struct {
+	paths  []string
+	stamps []fileStamp
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// check returns the current stamps of the files and whether they differ from the ones recorded on the last load.
+func (watch *fileWatch) check() ([]fileStamp, bool, error) {
+	stamps := make([]fileStamp, len(watch.paths))
+	changed := watch.stamps == nil
+
+	for i, path := range watch.paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, false, err
+		}
+
+		stamps[i] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		if !changed && (!stamps[i].modTime.Equal(watch.stamps[i].modTime) || stamps[i].size != watch.stamps[i].size) {
+			changed = true
+		}
+	}
+
+	return stamps, changed, nil
+}
@@ -0,0 +1,112 @@
+package kurrentdb
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Resolver looks up the DNS records used to discover a cluster. *net.Resolver implements it, and a resolver pointing at
+// a specific DNS server can be built with net.Resolver's Dial field.
+type Resolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// discoveryCandidate is a node gossip is read from during discovery.
+type discoveryCandidate struct {
+	address string
+	// Name the node's certificate is checked against when address is an IP resolved from DNS, empty otherwise.
+	authority string
+}
+
+// discoveryCandidates returns the nodes to read gossip from, resolving DNS records when DnsDiscover is set. A name that
+// can't be resolved is kept as a candidate so that the gRPC resolver gets a chance at it.
+func discoveryCandidates(ctx context.Context, conf *Configuration, logger *logger) []discoveryCandidate {
+	var seeds []string
+	if len(conf.GossipSeeds) > 0 {
+		for _, seed := range conf.GossipSeeds {
+			seeds = append(seeds, seed.String())
+		}
+	} else {
+		seeds = append(seeds, conf.Address)
+	}
+
+	candidates := make([]discoveryCandidate, 0, len(seeds))
+	for _, seed := range seeds {
+		if !conf.DnsDiscover {
+			candidates = append(candidates, discoveryCandidate{address: seed})
+			continue
+		}
+
+		resolved, err := resolveSeed(ctx, conf.resolver(), seed)
+		if err != nil {
+			logger.withError(err).warn("error when resolving DNS records, using the name as is", LogKeyEndpoint, seed)
+			candidates = append(candidates, discoveryCandidate{address: seed})
+			continue
+		}
+
+		logger.debug("resolved DNS records", LogKeyEndpoint, seed, "candidates", len(resolved))
+		candidates = append(candidates, resolved...)
+	}
+
+	return candidates
+}
+
+// resolveSeed expands a host:port seed into the nodes its SRV records point to, or else into its addresses.
+func resolveSeed(ctx context.Context, resolver Resolver, seed string) ([]discoveryCandidate, error) {
+	host, port, err := net.SplitHostPort(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	if net.ParseIP(host) != nil {
+		return []discoveryCandidate{{address: seed}}, nil
+	}
+
+	var records []*net.SRV
+	if strings.HasPrefix(host, "_") {
+		_, records, err = resolver.LookupSRV(ctx, "", "", host)
+	} else {
+		_, records, err = resolver.LookupSRV(ctx, "kurrentdb", "tcp", host)
+	}
+
+	// Records come sorted by priority and shuffled by weight.
+	if err == nil && len(records) > 0 {
+		candidates := make([]discoveryCandidate, 0, len(records))
+		for _, record := range records {
+			target := strings.TrimSuffix(record.Target, ".")
+			candidates = append(candidates, discoveryCandidate{
+				address: net.JoinHostPort(target, strconv.Itoa(int(record.Port))),
+			})
+		}
+
+		return candidates, nil
+	}
+
+	addresses, err := resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+
+	candidates := make([]discoveryCandidate, 0, len(addresses))
+	for _, address := range addresses {
+		candidates = append(candidates, discoveryCandidate{
+			address:   net.JoinHostPort(address, port),
+			authority: host,
+		})
+	}
+
+	shuffleCandidates(candidates)
+	return candidates, nil
+}
+
+func (conf *Configuration) resolver() Resolver {
+	if conf.Resolver != nil {
+		return conf.Resolver
+	}
+
+	return net.DefaultResolver
+}
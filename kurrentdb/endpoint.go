@@ -8,8 +8,6 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
-
-	"google.golang.org/grpc/credentials"
 )
 
 // EndPoint is database node endpoint.
@@ -74,25 +72,30 @@ func ParseEndPoint(s string) (*EndPoint, error) {
 func newGrpcClient(config Configuration) *grpcClient {
 	channel := make(chan msg)
 	closeFlag := new(int32)
-	logger := logger{
-		callback: config.Logger,
-	}
+	logger := newLogger(&config)
+
+	telemetry := newTelemetry(&config)
+	events := newConnectionEvents()
 
 	atomic.StoreInt32(closeFlag, 0)
 
-	go connectionStateMachine(config, closeFlag, channel, &logger)
+	breaker := newCircuitBreaker(config.CircuitBreakerCooldown)
 
-	// Maybe construct RPC credentials from client config.
-	var perRPCCredentials credentials.PerRPCCredentials
-	if config.Username != "" && config.Password != "" && !config.DisableTLS {
-		perRPCCredentials = newBasicAuthPerRPCCredentials(config.Username, config.Password)
+	// The certificate files are watched for the whole life of the client, so that every connection uses the latest.
+	if config.CertificateProvider == nil && config.UserCertFile != "" && config.UserKeyFile != "" {
+		config.CertificateProvider = NewFileCertificateProvider(config.UserCertFile, config.UserKeyFile)
 	}
 
+	go connectionStateMachine(config, channel, logger, telemetry, events, breaker)
+
 	return &grpcClient{
-		channel:           channel,
-		closeFlag:         closeFlag,
-		once:              new(sync.Once),
-		logger:            &logger,
-		perRPCCredentials: perRPCCredentials,
+		channel:     channel,
+		closeFlag:   closeFlag,
+		once:        new(sync.Once),
+		logger:      logger,
+		telemetry:   telemetry,
+		events:      events,
+		credentials: newCallCredentials(&config, logger),
+		breaker:     breaker,
 	}
 }
@@ -53,6 +53,10 @@ const (
 	ErrorUnavailable
 	// ErrorCodeAppendConsistencyViolation when one or more consistency checks failed during an AppendRecords operation.
 	ErrorCodeAppendConsistencyViolation
+	// ErrorCodeUnknownEventType when serializing or deserializing an event whose type is not registered.
+	ErrorCodeUnknownEventType
+	// ErrorCodeCircuitOpen when the client failed to discover a node recently and fails fast until it tries again.
+	ErrorCodeCircuitOpen
 )
 
 // Error main client error type.
@@ -110,6 +114,10 @@ func (e *Error) Error() string {
 		msg = "[ErrorUnavailable] the server is not ready to accept requests"
 	case ErrorCodeAppendConsistencyViolation:
 		msg = "[ErrorCodeAppendConsistencyViolation] one or more consistency checks failed during append"
+	case ErrorCodeUnknownEventType:
+		msg = "[ErrorCodeUnknownEventType] the event type is not registered in the serializer"
+	case ErrorCodeCircuitOpen:
+		msg = "[ErrorCodeCircuitOpen] no node could be discovered recently, the client fails fast until it tries again"
 
 	default:
 		msg = fmt.Sprintf("[ErrorCode %d] (sorry, this error code is not supported by the Error() method)", e.code)
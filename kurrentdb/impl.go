@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"math/rand"
 	"strconv"
@@ -27,11 +28,14 @@ import (
 )
 
 type grpcClient struct {
-	channel           chan msg
-	closeFlag         *int32
-	once              *sync.Once
-	logger            *logger
-	perRPCCredentials credentials.PerRPCCredentials
+	channel     chan msg
+	closeFlag   *int32
+	once        *sync.Once
+	logger      *logger
+	telemetry   *telemetry
+	events      *connectionEvents
+	credentials *callCredentials
+	breaker     *circuitBreaker
 }
 
 func (client *grpcClient) handleError(handle *connectionHandle, trailers metadata.MD, err error) error {
@@ -65,11 +69,13 @@ func (client *grpcClient) handleError(handle *connectionHandle, trailers metadat
 				msg := reconnect{
 					correlation: handle.Id(),
 					endpoint:    &endpoint,
+					err:         &Error{code: ErrorCodeNotLeader},
 				}
 
 				if !client.isClosed() {
 					client.channel <- msg
-					client.logger.error("not leader exception, reconnecting to %v", endpoint)
+					client.logger.warn("not leader exception, reconnecting to leader",
+						LogKeyConnectionId, handle.Id(), LogKeyEndpoint, endpoint.String(), LogKeyErrorCode, int(ErrorCodeNotLeader))
 					return &Error{code: ErrorCodeNotLeader}
 				}
 
@@ -86,19 +92,27 @@ func (client *grpcClient) handleError(handle *connectionHandle, trailers metadat
 		return &Error{code: ErrorCodeStreamDeleted, err: fmt.Errorf("stream '%s' is deleted", streamName)}
 	}
 
-	client.logger.error("unexpected exception: %v", err)
-
 	code := errToCode(err)
+	client.logger.error("unexpected exception",
+		LogKeyConnectionId, handle.Id(), LogKeyError, err, LogKeyErrorCode, int(code))
 	if code == ErrorUnavailable && !client.isClosed() {
 		client.channel <- reconnect{
 			correlation: handle.Id(),
+			err:         err,
 		}
 	}
 
 	return &Error{code: code, err: err}
 }
 
-func (client *grpcClient) getConnectionHandle() (*connectionHandle, error) {
+func (client *grpcClient) getConnectionHandle(ctx context.Context) (*connectionHandle, error) {
+	return client.getConnectionHandleFor(ctx, "")
+}
+
+// getConnectionHandleFor returns a handle to the node picked by a NodePreference, the configuration's if empty. If the
+// client isn't connected yet, node discovery runs under the given context, which operations bound by their deadline
+// beforehand, see withOperationDeadline.
+func (client *grpcClient) getConnectionHandleFor(ctx context.Context, preference NodePreference) (*connectionHandle, error) {
 	if client.isClosed() {
 		return nil, &Error{
 			code: ErrorCodeConnectionClosed,
@@ -106,12 +120,26 @@ func (client *grpcClient) getConnectionHandle() (*connectionHandle, error) {
 		}
 	}
 
-	msg := newGetConnectionMsg()
-	client.channel <- msg
+	// Fail fast rather than queue behind the state machine while the circuit is open.
+	if err := client.breaker.check(); err != nil {
+		return nil, err
+	}
+
+	msg := newGetConnectionMsg(ctx)
+	msg.preference = preference
 
-	resp := <-msg.channel
+	select {
+	case client.channel <- msg:
+	case <-ctx.Done():
+		return nil, contextError(ctx)
+	}
 
-	return &resp, resp.err
+	select {
+	case resp := <-msg.channel:
+		return &resp, resp.err
+	case <-ctx.Done():
+		return nil, contextError(ctx)
+	}
 }
 
 func (client *grpcClient) close() {
@@ -126,14 +154,26 @@ func (client *grpcClient) isClosed() bool {
 }
 
 type getConnection struct {
+	// Context of the operation asking for a connection, under which discovery runs.
+	ctx     context.Context
 	channel chan connectionHandle
+	// Node preference of the operation, empty to use the configuration's.
+	preference NodePreference
 }
 
 func (msg getConnection) isMsg() {}
 
-func newGetConnectionMsg() getConnection {
+// reply answers the operation with a handle.
+func (msg getConnection) reply(handle connectionHandle) {
+	msg.channel <- handle
+	close(msg.channel)
+}
+
+func newGetConnectionMsg(ctx context.Context) getConnection {
 	return getConnection{
-		channel: make(chan connectionHandle),
+		ctx: ctx,
+		// Buffered so that the state machine never blocks on an operation that gave up waiting.
+		channel: make(chan connectionHandle, 1),
 	}
 }
 
@@ -143,6 +183,18 @@ type connectionState struct {
 	serverInfo  *serverInfo
 	config      Configuration
 	lastError   error
+	// Endpoint of the last leader the client connected to.
+	leader *EndPoint
+	// Node the current connection targets.
+	node *discoveredNode
+	// Connections replaced by a better node, closed once their in-flight RPCs are done.
+	retired []*connectionDrain
+	// Tells if a topology refresh is running.
+	refreshing bool
+	// Connections to the nodes picked by other preferences than the configuration's.
+	pool map[NodePreference]*pooledConnection
+	// Operations waiting for a connection to the node picked by a preference, which is being connected to.
+	dialing map[NodePreference][]getConnection
 }
 
 func newConnectionState(config Configuration) connectionState {
@@ -212,18 +264,185 @@ func newConnectionHandle(id uuid.UUID, serverInfo *serverInfo, connection *grpc.
 	}
 }
 
-func connectionStateMachine(config Configuration, closeFlag *int32, channel chan msg, logger *logger) {
+// connected records the node the state machine is now connected to and publishes the matching events.
+func (state *connectionState) connected(events *connectionEvents, node *discoveredNode, serverInfo *serverInfo) {
+	state.node = node
+
+	if node.state == NodeStateLeader {
+		if state.leader != nil && *state.leader != node.endpoint {
+			events.publish(&ConnectionEvent{
+				LeaderChanged: &ConnectionLeaderChanged{Previous: state.leader, Leader: node.endpoint},
+			})
+		}
+
+		leader := node.endpoint
+		state.leader = &leader
+	}
+
+	var version ServerVersion
+	if serverInfo != nil {
+		version = serverInfo.version
+	}
+
+	events.publish(&ConnectionEvent{
+		Connected: &ConnectionConnected{Endpoint: node.endpoint, ServerVersion: version, NodeState: node.state},
+	})
+}
+
+// retire lets the current connection drain instead of closing it, so that in-flight operations and subscriptions
+// complete on the node they started on.
+func (state *connectionState) retire() {
+	if state.node == nil || state.node.drain == nil {
+		_ = state.connection.Close()
+		return
+	}
+
+	state.node.drain.retire()
+
+	retired := make([]*connectionDrain, 0, len(state.retired)+1)
+	for _, drain := range state.retired {
+		if atomic.LoadInt64(&drain.active) > 0 {
+			retired = append(retired, drain)
+		}
+	}
+
+	state.retired = append(retired, state.node.drain)
+}
+
+func connectionStateMachine(config Configuration, channel chan msg, logger *logger, telemetry *telemetry, events *connectionEvents, breaker *circuitBreaker) {
 	state := newConnectionState(config)
+	defer events.publish(&ConnectionEvent{Closed: &ConnectionClosed{}})
+
+	// Topology refreshes run in the background and report back on their own channel, as the message channel is closed
+	// with the client.
+	var refreshTicks <-chan time.Time
+	if config.GossipRefreshInterval > 0 && isClusterConnection(&config) {
+		ticker := time.NewTicker(config.GossipRefreshInterval)
+		defer ticker.Stop()
+		refreshTicks = ticker.C
+	}
+
+	refreshes := make(chan *topologyRefresh)
+	preferred := make(chan *preferredConnection)
+	done := make(chan struct{})
+	defer close(done)
+
+	serve := func(evt getConnection) {
+		// Means we need to create a grpc connection.
+		if state.correlation == uuid.Nil {
+			if err := breaker.check(); err != nil {
+				evt.reply(newErroredConnectionHandle(err))
+				return
+			}
+
+			events.publish(&ConnectionEvent{Discovering: &ConnectionDiscovering{}})
+			conn, serverInfo, node, err := discoverNode(evt.ctx, state.config, logger)
+
+			if err != nil && evt.ctx.Err() != nil {
+				// The operation gave up, the next one starts a new discovery process.
+				logger.withError(err).info("discovery interrupted by the operation's context")
+				events.publish(&ConnectionEvent{Disconnected: &ConnectionDisconnected{Error: err}})
+				evt.reply(newErroredConnectionHandle(err))
+				return
+			}
+
+			if err != nil {
+				// The outage may be transient, so the client stays usable and tries again once the circuit
+				// half-opens.
+				breaker.trip(err)
+				state.lastError = err
+				logger.withError(err).warn("discovery failed, failing fast until the next attempt",
+					"cooldown", state.config.CircuitBreakerCooldown)
+				events.publish(&ConnectionEvent{Disconnected: &ConnectionDisconnected{Error: err}})
+				evt.reply(newErroredConnectionHandle(err))
+				return
+			}
+
+			breaker.reset()
+			state.correlation = uuid.New()
+			state.connection = conn
+			state.serverInfo = serverInfo
+			logger.info("connected to node", LogKeyConnectionId, state.correlation, LogKeyEndpoint, conn.Target())
+			state.connected(events, node, serverInfo)
+		}
+
+		state.handleFor(evt, preferred, done)
+	}
 
 	for {
-		msg, ok := <-channel
+		var msg msg
+		var ok bool
+
+		select {
+		case msg, ok = <-channel:
+		case <-refreshTicks:
+			if state.correlation != uuid.Nil && state.node != nil && !state.refreshing {
+				state.refreshing = true
+				go func(conf Configuration, correlation uuid.UUID, conn *grpc.ClientConn, current EndPoint) {
+					refresh := refreshTopology(&conf, logger, correlation, conn, current)
+
+					select {
+					case refreshes <- refresh:
+					case <-done:
+						if refresh != nil {
+							_ = refresh.connection.Close()
+						}
+					}
+				}(state.config, state.correlation, state.connection, state.node.endpoint)
+			}
+
+			continue
+		case refresh := <-refreshes:
+			state.refreshing = false
+			if refresh == nil {
+				continue
+			}
+
+			if refresh.correlation != state.correlation {
+				// The connection changed while gossip was read, the refresh is stale.
+				_ = refresh.connection.Close()
+				continue
+			}
+
+			state.retire()
+			state.resetPool()
+			state.correlation = uuid.New()
+			state.connection = refresh.connection
+			state.serverInfo = refresh.serverInfo
+			logger.info("migrated to better node", LogKeyConnectionId, state.correlation, LogKeyEndpoint, refresh.connection.Target())
+			state.connected(events, refresh.node, refresh.serverInfo)
+
+			continue
+		case result := <-preferred:
+			for _, evt := range state.connectedPreferred(result, logger) {
+				serve(evt)
+			}
+
+			continue
+		}
 
 		if !ok {
 			if state.connection != nil {
 				err := state.connection.Close()
 
 				if err != nil {
-					logger.warn("error when closing gRPC connection. %v", err)
+					logger.withError(err).warn("error when closing gRPC connection", LogKeyConnectionId, state.correlation)
+				}
+			}
+
+			for _, drain := range state.retired {
+				drain.close()
+			}
+
+			for _, pooled := range state.pool {
+				if !pooled.shared {
+					_ = pooled.connection.Close()
+				}
+			}
+
+			for _, waiting := range state.dialing {
+				for _, evt := range waiting {
+					evt.reply(newErroredConnectionHandle(&Error{code: ErrorCodeConnectionClosed, err: fmt.Errorf("connection is closed")}))
 				}
 			}
 
@@ -232,45 +451,22 @@ func connectionStateMachine(config Configuration, closeFlag *int32, channel chan
 
 		switch evt := msg.(type) {
 		case getConnection:
-			{
-				// Means we need to create a grpc connection.
-				if state.correlation == uuid.Nil {
-					conn, serverInfo, err := discoverNode(state.config, logger)
-
-					if err != nil {
-						atomic.StoreInt32(closeFlag, 1)
-						state.lastError = err
-						resp := newErroredConnectionHandle(err)
-						evt.channel <- resp
-						close(evt.channel)
-						return
-					}
-
-					state.correlation = uuid.New()
-					state.connection = conn
-					state.serverInfo = serverInfo
-
-					resp := newConnectionHandle(state.correlation, serverInfo, conn)
-					evt.channel <- resp
-					close(evt.channel)
-				} else {
-					handle := connectionHandle{
-						id:         state.correlation,
-						connection: state.connection,
-						serverInfo: state.serverInfo,
-						err:        nil,
-					}
-
-					evt.channel <- handle
-					close(evt.channel)
-				}
-			}
+			serve(evt)
 		case reconnect:
+			if state.dropPooled(evt.correlation) {
+				logger.withError(evt.err).info("dropped connection to preferred node", LogKeyConnectionId, evt.correlation)
+				continue
+			}
+
 			if evt.correlation == state.correlation {
+				state.resetPool()
+				events.publish(&ConnectionEvent{Disconnected: &ConnectionDisconnected{Error: evt.err}})
+
 				if evt.endpoint == nil {
+					telemetry.recordReconnect("discovery")
 					// Means that in the next iteration cycle, the discovery process will start.
 					state.correlation = uuid.Nil
-					logger.info("starting a new discovery process")
+					logger.info("starting a new discovery process", LogKeyConnectionId, evt.correlation)
 					continue
 				}
 
@@ -279,18 +475,27 @@ func connectionStateMachine(config Configuration, closeFlag *int32, channel chan
 					state.connection = nil
 				}
 
-				logger.info("Connecting to leader node %s ...", evt.endpoint.String())
-				conn, err := createGrpcConnection(&state.config, evt.endpoint.String())
+				telemetry.recordReconnect("not_leader")
+				events.publish(&ConnectionEvent{
+					LeaderChanged: &ConnectionLeaderChanged{Previous: state.leader, Leader: *evt.endpoint},
+				})
+				state.leader = evt.endpoint
+
+				nodeLogger := logger.with(LogKeyEndpoint, evt.endpoint.String())
+				nodeLogger.info("connecting to leader node", LogKeyConnectionId, evt.correlation)
+				conn, drain, err := createGrpcConnection(&state.config, evt.endpoint.String())
 
 				if err != nil {
-					logger.error("exception when connecting to suggested node %s", evt.endpoint.String())
+					nodeLogger.withError(err).error("exception when connecting to suggested node")
+					events.publish(&ConnectionEvent{Disconnected: &ConnectionDisconnected{Error: err}})
 					state.correlation = uuid.Nil
 					continue
 				}
 
 				serverInfo, err := getSupportedMethods(context.Background(), &state.config, conn)
 				if err != nil {
-					logger.error("exception when fetching server features from suggested node %s: %v", evt.endpoint.String(), err)
+					nodeLogger.withError(err).error("exception when fetching server features from suggested node")
+					events.publish(&ConnectionEvent{Disconnected: &ConnectionDisconnected{Error: err}})
 					state.correlation = uuid.Nil
 					continue
 				}
@@ -299,7 +504,8 @@ func connectionStateMachine(config Configuration, closeFlag *int32, channel chan
 				state.connection = conn
 				state.serverInfo = serverInfo
 
-				logger.info("successfully connected to leader node %s", evt.endpoint.String())
+				nodeLogger.info("successfully connected to leader node", LogKeyConnectionId, state.correlation)
+				state.connected(events, &discoveredNode{endpoint: *evt.endpoint, state: NodeStateLeader, drain: drain}, serverInfo)
 			}
 		}
 	}
@@ -308,14 +514,17 @@ func connectionStateMachine(config Configuration, closeFlag *int32, channel chan
 type reconnect struct {
 	correlation uuid.UUID
 	endpoint    *EndPoint
+	// Error that caused the reconnection.
+	err error
 }
 
 func (msg reconnect) isMsg() {}
 
 const maxInboundMessageLength = 17 * 1_024 * 1_024 // 17 MiB
 
-func createGrpcConnection(conf *Configuration, address string) (*grpc.ClientConn, error) {
-	var opts []grpc.DialOption
+func createGrpcConnection(conf *Configuration, address string, dialOptions ...grpc.DialOption) (*grpc.ClientConn, *connectionDrain, error) {
+	drain := &connectionDrain{}
+	opts := append([]grpc.DialOption{grpc.WithStatsHandler(drain)}, dialOptions...)
 	var transport credentials.TransportCredentials
 
 	if conf.DisableTLS {
@@ -326,18 +535,28 @@ func createGrpcConnection(conf *Configuration, address string) (*grpc.ClientConn
 			RootCAs:            conf.RootCAs,
 		}
 
-		if conf.UserCertFile != "" && conf.UserKeyFile != "" {
-			cert, err := tls.LoadX509KeyPair(conf.UserCertFile, conf.UserKeyFile)
-			if err != nil {
-				return nil, fmt.Errorf("failed to load user certificate: %w", err)
+		if provider := conf.CertificateProvider; provider != nil {
+			// Fail on a certificate that can't be loaded now rather than on the handshake, which the provider is
+			// consulted by again, so that a rotated certificate is picked up.
+			if _, err := provider.Certificate(); err != nil {
+				return nil, nil, fmt.Errorf("failed to load user certificate: %w", err)
+			}
+
+			tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+				return provider.Certificate()
 			}
-			tlsConfig.Certificates = []tls.Certificate{cert}
 		}
 
 		transport = credentials.NewTLS(tlsConfig)
 	}
 
 	opts = append(opts, grpc.WithTransportCredentials(transport))
+	if conf.ConnectionName != "" {
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(connectionNameUnaryInterceptor(conf.ConnectionName)),
+			grpc.WithChainStreamInterceptor(connectionNameStreamInterceptor(conf.ConnectionName)))
+	}
+
 	opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxInboundMessageLength)))
 	if conf.KeepAliveInterval >= 0 {
 		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
@@ -349,10 +568,26 @@ func createGrpcConnection(conf *Configuration, address string) (*grpc.ClientConn
 
 	conn, err := grpc.NewClient(address, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize connection to %s. Reason: %w", address, err)
+		return nil, nil, fmt.Errorf("failed to initialize connection to %s. Reason: %w", address, err)
+	}
+
+	drain.conn = conn
+	return conn, drain, nil
+}
+
+// connectionNameHeader carries the configuration's ConnectionName with every call.
+const connectionNameHeader = "connection-name"
+
+func connectionNameUnaryInterceptor(name string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(metadata.AppendToOutgoingContext(ctx, connectionNameHeader, name), method, req, reply, cc, opts...)
 	}
+}
 
-	return conn, nil
+func connectionNameStreamInterceptor(name string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(metadata.AppendToOutgoingContext(ctx, connectionNameHeader, name), desc, cc, method, opts...)
+	}
 }
 
 const (
@@ -457,11 +692,14 @@ func getSupportedMethods(ctx context.Context, conf *Configuration, conn *grpc.Cl
 }
 
 func newBasicAuthPerRPCCredentials(username, password string) *basicAuthPerRPCCredentials {
-	authorization := "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
-	headers := map[string]string{"Authorization": authorization}
+	headers := map[string]string{"Authorization": basicAuthorization(username, password)}
 	return &basicAuthPerRPCCredentials{headers: headers}
 }
 
+func basicAuthorization(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
 type basicAuthPerRPCCredentials struct {
 	headers map[string]string
 }
@@ -474,6 +712,45 @@ func (*basicAuthPerRPCCredentials) RequireTransportSecurity() bool {
 	return true
 }
 
+// providedPerRPCCredentials asks a CredentialsProvider for the credentials of every call.
+type providedPerRPCCredentials struct {
+	provider CredentialsProvider
+}
+
+// newCredentialsPerRPCCredentials sends the same credentials with every call, getting a token every time if they carry
+// a TokenSource.
+func newCredentialsPerRPCCredentials(creds *Credentials) credentials.PerRPCCredentials {
+	if creds.Token == nil {
+		return newBasicAuthPerRPCCredentials(creds.Login, creds.Password)
+	}
+
+	return &providedPerRPCCredentials{provider: CredentialsProviderFunc(func(context.Context) (*Credentials, error) {
+		return creds, nil
+	})}
+}
+
+func (p *providedPerRPCCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	creds, err := p.provider.Credentials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials: %w", err)
+	}
+
+	if creds == nil {
+		return nil, nil
+	}
+
+	authorization, err := creds.authorization(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"Authorization": authorization}, nil
+}
+
+func (*providedPerRPCCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
 func allowedNodeState() []gossipApi.MemberInfo_VNodeState {
 	return []gossipApi.MemberInfo_VNodeState{
 		gossipApi.MemberInfo_Follower,
@@ -484,52 +761,84 @@ func allowedNodeState() []gossipApi.MemberInfo_VNodeState {
 	}
 }
 
-func discoverNode(conf Configuration, logger *logger) (*grpc.ClientConn, *serverInfo, error) {
+// discoveredNode describes the node picked by discoverNode.
+type discoveredNode struct {
+	endpoint EndPoint
+	state    NodeState
+	drain    *connectionDrain
+}
+
+func newDiscoveredNode(address string, state NodeState) *discoveredNode {
+	endpoint, err := ParseEndPoint(address)
+	if err != nil {
+		return &discoveredNode{endpoint: EndPoint{Host: address}, state: state}
+	}
+
+	return &discoveredNode{endpoint: *endpoint, state: state}
+}
+
+func discoverNode(ctx context.Context, conf Configuration, logger *logger) (*grpc.ClientConn, *serverInfo, *discoveredNode, error) {
 	var serverInfo *serverInfo = nil
 	var lastErr error
-	var candidates []string
 
 	attempt := 0
 	// We still need to keep tracking that state until 20.10 end of life, as GossipOnSingleNode is still present in that
 	// version.
-	clusterMode := false
-
-	if conf.DnsDiscover {
-		clusterMode = true
-		candidates = append(candidates, conf.Address)
-	} else if len(conf.GossipSeeds) > 0 {
-		clusterMode = true
-		for _, seed := range conf.GossipSeeds {
-			candidates = append(candidates, seed.String())
-		}
-	} else {
-		candidates = append(candidates, conf.Address)
-	}
-
-	if clusterMode {
-		shuffleCandidates(candidates)
-	}
+	clusterMode := isClusterConnection(&conf)
 
 	for attempt < conf.MaxDiscoverAttempts {
+		if attempt > 0 {
+			backoff := discoveryBackoff(&conf, attempt)
+			logger.debug("waiting before next discovery attempt", LogKeyAttempt, attempt+1, "backoff", backoff)
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, nil, nil, contextError(ctx)
+			}
+		}
+
 		attempt += 1
-		logger.info("discovery attempt %v/%v", attempt, conf.MaxDiscoverAttempts)
-		for _, candidate := range candidates {
-			logger.debug("trying candidate '%s'...", candidate)
-			connection, err := createGrpcConnection(&conf, candidate)
+		attemptLogger := logger.with(LogKeyAttempt, attempt)
+		attemptLogger.info("discovery attempt", "max_attempts", conf.MaxDiscoverAttempts)
+
+		// DNS records are resolved again on every attempt, as the cluster may have moved since the last one.
+		candidates := discoveryCandidates(ctx, &conf, attemptLogger)
+		if clusterMode && !conf.DnsDiscover {
+			shuffleCandidates(candidates)
+		}
+
+		for _, seed := range candidates {
+			candidate := seed.address
+			if ctx.Err() != nil {
+				return nil, nil, nil, contextError(ctx)
+			}
+
+			nodeState := NodeStateUnknown
+			candidateLogger := attemptLogger.with(LogKeyEndpoint, candidate)
+			candidateLogger.debug("trying candidate")
+			var dialOptions []grpc.DialOption
+			if seed.authority != "" {
+				dialOptions = append(dialOptions, grpc.WithAuthority(seed.authority))
+			}
+
+			connection, drain, err := createGrpcConnection(&conf, candidate, dialOptions...)
 			if err != nil {
-				logger.warn("error when creating a grpc connection for candidate %s: %v", candidate, err)
+				candidateLogger.withError(err).warn("error when creating a grpc connection for candidate")
 				lastErr = err
 				continue
 			}
 
 			if clusterMode {
 				client := gossipApi.NewGossipClient(connection)
-				ctx, cancel := context.WithTimeout(context.Background(), time.Duration(conf.GossipTimeout)*time.Second)
-				info, err := client.Read(ctx, &shared.Empty{})
+				gossipCtx, cancel := context.WithTimeout(ctx, time.Duration(conf.GossipTimeout)*time.Second)
+				info, err := client.Read(gossipCtx, &shared.Empty{})
 
 				s, ok := status.FromError(err)
 				if !ok || (s != nil && s.Code() != codes.OK) {
-					logger.warn("error when reading gossip from candidate %s: %v", candidate, err)
+					candidateLogger.withError(err).warn("error when reading gossip from candidate")
 					lastErr = err
 					cancel()
 					_ = connection.Close()
@@ -542,7 +851,7 @@ func discoverNode(conf Configuration, logger *logger) (*grpc.ClientConn, *server
 				selected, err := pickBestCandidate(info, conf.NodePreference)
 
 				if err != nil {
-					logger.warn("error when picking best candidate out of %s gossip response: %v", candidate, err)
+					candidateLogger.withError(err).warn("error when picking best candidate out of gossip response")
 					lastErr = err
 					_ = connection.Close()
 					connection = nil
@@ -550,14 +859,16 @@ func discoverNode(conf Configuration, logger *logger) (*grpc.ClientConn, *server
 				}
 
 				selectedAddress := fmt.Sprintf("%s:%d", selected.GetHttpEndPoint().GetAddress(), selected.GetHttpEndPoint().GetPort())
-				logger.info("best candidate found. %s (%s)", selectedAddress, selected.State.String())
+				candidateLogger.info("best candidate found", "selected", selectedAddress, "state", selected.State.String())
+				nodeState = nodeStateFromProto(selected.State)
 				if candidate != selectedAddress {
 					candidate = selectedAddress
+					candidateLogger = attemptLogger.with(LogKeyEndpoint, candidate)
 					_ = connection.Close()
-					connection, err = createGrpcConnection(&conf, selectedAddress)
+					connection, drain, err = createGrpcConnection(&conf, selectedAddress)
 
 					if err != nil {
-						logger.warn("error when creating gRPC connection for the selected candidate '%s': %v", selectedAddress, err)
+						candidateLogger.withError(err).warn("error when creating gRPC connection for the selected candidate")
 						lastErr = err
 						_ = connection.Close()
 						connection = nil
@@ -566,10 +877,10 @@ func discoverNode(conf Configuration, logger *logger) (*grpc.ClientConn, *server
 				}
 			}
 
-			logger.debug("attempting node supported features retrieval on '%s'...", candidate)
-			serverInfo, err = getSupportedMethods(context.Background(), &conf, connection)
+			candidateLogger.debug("attempting node supported features retrieval")
+			serverInfo, err = getSupportedMethods(ctx, &conf, connection)
 			if err != nil {
-				logger.warn("error when reading server features from the best candidate '%s': %v", candidate, err)
+				candidateLogger.withError(err).warn("error when reading server features from the best candidate")
 				lastErr = err
 				_ = connection.Close()
 				connection = nil
@@ -577,23 +888,56 @@ func discoverNode(conf Configuration, logger *logger) (*grpc.ClientConn, *server
 			}
 
 			if serverInfo != nil {
-				logger.debug("retrieved supported features on node '%s' successfully", candidate)
+				candidateLogger.debug("retrieved supported features successfully")
 			} else {
-				logger.debug("selected node '%s' doesn't support a supported features endpoint", candidate)
+				candidateLogger.debug("selected node doesn't support a supported features endpoint")
 			}
 
-			return connection, serverInfo, nil
-		}
+			node := newDiscoveredNode(candidate, nodeState)
+			node.drain = drain
 
-		time.Sleep(time.Duration(conf.DiscoveryInterval) * time.Millisecond)
+			return connection, serverInfo, node, nil
+		}
 	}
 
-	return nil, nil, &Error{
+	return nil, nil, nil, &Error{
 		code: errToCode(lastErr),
 		err:  fmt.Errorf("maximum discovery attempt count reached: %v. Last Error: %w", conf.MaxDiscoverAttempts, lastErr),
 	}
 }
 
+// maxDiscoveryBackoff caps the wait between discovery attempts, unless DiscoveryInterval is longer.
+const maxDiscoveryBackoff = 5 * time.Second
+
+// discoveryBackoff returns how long to wait after a failed discovery attempt. The wait doubles from DiscoveryInterval
+// with every attempt, and half of it is random so that clients restarted together don't hit the cluster in lockstep.
+func discoveryBackoff(conf *Configuration, attempt int) time.Duration {
+	backoff := time.Duration(conf.DiscoveryInterval) * time.Millisecond
+	ceiling := max(maxDiscoveryBackoff, backoff)
+
+	for i := 1; i < attempt && backoff < ceiling; i++ {
+		backoff *= 2
+	}
+
+	backoff = min(backoff, ceiling)
+	if backoff <= 0 {
+		return 0
+	}
+
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
+
+// contextError reports an operation that gave up because its context is done.
+func contextError(ctx context.Context) error {
+	code := ErrorCodeUnknown
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		code = ErrorCodeDeadlineExceeded
+	}
+
+	return &Error{code: code, err: ctx.Err()}
+}
+
 // In that case, `err` is always != nil
 func errToCode(err error) ErrorCode {
 	var code ErrorCode
@@ -621,7 +965,7 @@ func errToCode(err error) ErrorCode {
 	return code
 }
 
-func shuffleCandidates(src []string) []string {
+func shuffleCandidates(src []discoveryCandidate) []discoveryCandidate {
 	rand.Shuffle(len(src), func(i, j int) {
 		src[i], src[j] = src[j], src[i]
 	})
@@ -1,8 +1,12 @@
 package kurrentdb
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
+	"strconv"
 	"strings"
 )
 
@@ -16,6 +20,16 @@ const (
 	LogError LogLevel = "error"
 )
 
+// Attribute keys of the structured log records emitted by the client.
+const (
+	LogKeyConnectionId   = "connection_id"
+	LogKeyEndpoint       = "endpoint"
+	LogKeyAttempt        = "attempt"
+	LogKeySubscriptionId = "subscription_id"
+	LogKeyErrorCode      = "error_code"
+	LogKeyError          = "error"
+)
+
 // LoggingFunc main logging abstraction.
 type LoggingFunc = func(level LogLevel, format string, args ...interface{})
 
@@ -35,30 +49,179 @@ func NoopLogging() LoggingFunc {
 	}
 }
 
-type logger struct {
+// NewLoggingFuncHandler returns a slog.Handler forwarding records to a LoggingFunc. Attributes are appended to the
+// message as key=value pairs. A nil callback discards every record.
+func NewLoggingFuncHandler(callback LoggingFunc) slog.Handler {
+	return &loggingFuncHandler{callback: callback}
+}
+
+type loggingFuncHandler struct {
 	callback LoggingFunc
+	// Attributes added through WithAttrs, already formatted.
+	attrs string
+	// Prefix of the attribute keys, built from the groups opened through WithGroup.
+	prefix string
+}
+
+func (handler *loggingFuncHandler) Enabled(context.Context, slog.Level) bool {
+	return handler.callback != nil
 }
 
-func (log *logger) error(format string, args ...interface{}) {
-	if log.callback != nil {
-		log.callback(LogError, format, args...)
+func (handler *loggingFuncHandler) Handle(_ context.Context, record slog.Record) error {
+	var builder strings.Builder
+	builder.WriteString(record.Message)
+	builder.WriteString(handler.attrs)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		writeAttr(&builder, handler.prefix, attr)
+		return true
+	})
+
+	handler.callback(logLevelOf(record.Level), "%s", builder.String())
+	return nil
+}
+
+func (handler *loggingFuncHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var builder strings.Builder
+	builder.WriteString(handler.attrs)
+
+	for _, attr := range attrs {
+		writeAttr(&builder, handler.prefix, attr)
 	}
+
+	return &loggingFuncHandler{callback: handler.callback, attrs: builder.String(), prefix: handler.prefix}
 }
 
-func (log *logger) warn(format string, args ...interface{}) {
-	if log.callback != nil {
-		log.callback(LogWarn, format, args...)
+func (handler *loggingFuncHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return handler
 	}
+
+	return &loggingFuncHandler{callback: handler.callback, attrs: handler.attrs, prefix: handler.prefix + name + "."}
 }
 
-func (log *logger) debug(format string, args ...interface{}) {
-	if log.callback != nil {
-		log.callback(LogDebug, format, args...)
+func writeAttr(builder *strings.Builder, prefix string, attr slog.Attr) {
+	value := attr.Value.Resolve()
+
+	if value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+
+		for _, nested := range value.Group() {
+			writeAttr(builder, prefix, nested)
+		}
+
+		return
+	}
+
+	if attr.Key == "" {
+		return
+	}
+
+	text := value.String()
+	if strings.ContainsAny(text, " =\"") || text == "" {
+		text = strconv.Quote(text)
+	}
+
+	builder.WriteString(" ")
+	builder.WriteString(prefix)
+	builder.WriteString(attr.Key)
+	builder.WriteString("=")
+	builder.WriteString(text)
+}
+
+func logLevelOf(level slog.Level) LogLevel {
+	switch {
+	case level < slog.LevelInfo:
+		return LogDebug
+	case level < slog.LevelWarn:
+		return LogInfo
+	case level < slog.LevelError:
+		return LogWarn
+	default:
+		return LogError
 	}
 }
 
-func (log *logger) info(format string, args ...interface{}) {
-	if log.callback != nil {
-		log.callback(LogInfo, format, args...)
+func slogLevelOf(level LogLevel) slog.Level {
+	switch level {
+	case LogDebug:
+		return slog.LevelDebug
+	case LogWarn:
+		return slog.LevelWarn
+	case LogError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
 	}
 }
+
+type logger struct {
+	slog *slog.Logger
+}
+
+// newLogger uses the configuration's structured logger, falling back to its LoggingFunc.
+func newLogger(conf *Configuration) *logger {
+	var handler slog.Handler
+	if conf.StructuredLogger != nil {
+		handler = conf.StructuredLogger.Handler()
+	} else {
+		handler = NewLoggingFuncHandler(conf.Logger)
+	}
+
+	if conf.LogLevel != "" {
+		handler = &levelHandler{Handler: handler, level: slogLevelOf(conf.LogLevel)}
+	}
+
+	return &logger{slog: slog.New(handler)}
+}
+
+// levelHandler drops the records below the configured LogLevel.
+type levelHandler struct {
+	slog.Handler
+	level slog.Level
+}
+
+func (handler *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= handler.level && handler.Handler.Enabled(ctx, level)
+}
+
+func (handler *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{Handler: handler.Handler.WithAttrs(attrs), level: handler.level}
+}
+
+func (handler *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{Handler: handler.Handler.WithGroup(name), level: handler.level}
+}
+
+// with returns a logger adding the given attributes to every record.
+func (log *logger) with(args ...any) *logger {
+	return &logger{slog: log.slog.With(args...)}
+}
+
+// withError returns a logger adding the error, and its code if it is an *Error, to every record.
+func (log *logger) withError(err error) *logger {
+	var esErr *Error
+	if errors.As(err, &esErr) {
+		return log.with(LogKeyError, err, LogKeyErrorCode, int(esErr.Code()))
+	}
+
+	return log.with(LogKeyError, err)
+}
+
+func (log *logger) error(msg string, args ...any) {
+	log.slog.Error(msg, args...)
+}
+
+func (log *logger) warn(msg string, args ...any) {
+	log.slog.Warn(msg, args...)
+}
+
+func (log *logger) debug(msg string, args ...any) {
+	log.slog.Debug(msg, args...)
+}
+
+func (log *logger) info(msg string, args ...any) {
+	log.slog.Info(msg, args...)
+}
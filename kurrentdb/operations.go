@@ -1,5 +1,7 @@
 package kurrentdb
 
+import "context"
+
 // ServerVersion Represents the version of a KurrentDB node.
 type ServerVersion struct {
 	Major int
@@ -7,9 +9,13 @@ type ServerVersion struct {
 	Patch int
 }
 
-// GetServerVersion Returns the version of the KurrentDB node to which the client is currently connected.
+// GetServerVersion Returns the version of the KurrentDB node to which the client is currently connected. Connecting to
+// a node, if the client is not connected yet, is bounded by the configuration's DefaultDeadline, or 10 seconds.
 func (client *Client) GetServerVersion() (*ServerVersion, error) {
-	handle, err := client.grpcClient.getConnectionHandle()
+	ctx, cancel := context.WithTimeout(context.Background(), defaultDeadline(client.config))
+	defer cancel()
+
+	handle, err := client.grpcClient.getConnectionHandle(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -24,37 +23,81 @@ type options interface {
 	requiresLeader() bool
 }
 
-func configureGrpcCall(ctx context.Context, conf *Configuration, options options, grpcOptions []grpc.CallOption, perRPCCredentials credentials.PerRPCCredentials) ([]grpc.CallOption, context.Context, context.CancelFunc) {
-	return configureGrpcCall_(ctx, conf, options, grpcOptions, perRPCCredentials, true)
+// routedOptions is implemented by the options of operations able to run on another node than the one picked by the
+// configuration's NodePreference.
+type routedOptions interface {
+	nodePreference() NodePreference
 }
 
-func configureGrpcCall_(ctx context.Context, conf *Configuration, options options, grpcOptions []grpc.CallOption, perRPCCredentials credentials.PerRPCCredentials, forceForwardRequiresLeader bool) ([]grpc.CallOption, context.Context, context.CancelFunc) {
-	var duration time.Duration
+// preferenceOf returns the node preference of an operation, empty if it runs on the node picked by the configuration.
+func preferenceOf(options options) NodePreference {
+	if routed, ok := options.(routedOptions); ok {
+		return routed.nodePreference()
+	}
+
+	return ""
+}
 
+func configureGrpcCall(ctx context.Context, conf *Configuration, options options, grpcOptions []grpc.CallOption, creds *callCredentials) ([]grpc.CallOption, context.Context, context.CancelFunc) {
+	return configureGrpcCall_(ctx, conf, options, grpcOptions, creds, true)
+}
+
+// operationDeadline returns how long an operation may run, false if it has no deadline. Streaming operations only have
+// one when their options set it.
+func operationDeadline(conf *Configuration, options options) (time.Duration, bool) {
 	if options.deadline() != nil {
-		duration = *options.deadline()
-	} else if options.kind() != streamingOperation && conf.DefaultDeadline != nil {
-		duration = *conf.DefaultDeadline
+		return *options.deadline(), true
 	} else if options.kind() == streamingOperation {
+		return 0, false
+	}
+
+	return defaultDeadline(conf), true
+}
+
+// defaultDeadline returns the deadline of regular operations whose options do not set one.
+func defaultDeadline(conf *Configuration) time.Duration {
+	if conf.DefaultDeadline != nil {
+		return *conf.DefaultDeadline
+	}
+
+	return 10 * time.Second
+}
+
+// withOperationDeadline bounds ctx by the deadline of an operation. Operations obtain their connection handle under the
+// returned context, so that node discovery counts against the deadline as well as the call itself. ctx is returned
+// as is when the operation has no deadline.
+//
+// Streaming operations keep the returned context for as long as the stream is open and only cancel it when they fail
+// to open one. Their deadline comes from their options, and its timer releases the context once it passes.
+func withOperationDeadline(ctx context.Context, conf *Configuration, options options) (context.Context, context.CancelFunc) {
+	duration, ok := operationDeadline(conf, options)
+	if !ok {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, duration)
+}
+
+func configureGrpcCall_(ctx context.Context, conf *Configuration, options options, grpcOptions []grpc.CallOption, creds *callCredentials, forceForwardRequiresLeader bool) ([]grpc.CallOption, context.Context, context.CancelFunc) {
+	duration, ok := operationDeadline(conf, options)
+	if !ok {
 		duration = time.Duration(math.MaxInt64)
-	} else {
-		duration = 10 * time.Second
 	}
 
 	deadline := time.Now().Add(duration)
 	newCtx, cancel := context.WithDeadline(ctx, deadline)
 
-	// Maybe use RPC credentials from client method options instead of RPC credentials from client config.
-	if options.credentials() != nil && !conf.DisableTLS {
-		perRPCCredentials = newBasicAuthPerRPCCredentials(options.credentials().Login, options.credentials().Password)
-	}
-
 	// Maybe append RPC credentials to gRPC call options.
-	if perRPCCredentials != nil {
+	if perRPCCredentials := creds.grpcCredentials(options.credentials()); perRPCCredentials != nil {
 		grpcOptions = append(grpcOptions, grpc.PerRPCCredsCallOption{Creds: perRPCCredentials})
 	}
 
-	if forceForwardRequiresLeader && (options.requiresLeader() || conf.NodePreference == NodePreferenceLeader) {
+	preference := conf.NodePreference
+	if routed := preferenceOf(options); routed != "" {
+		preference = routed
+	}
+
+	if forceForwardRequiresLeader && (options.requiresLeader() || preference == NodePreferenceLeader) {
 		md := metadata.New(map[string]string{"requires-leader": "true"})
 		newCtx = metadata.NewIncomingContext(newCtx, md)
 	}
@@ -2,9 +2,9 @@ package kurrentdb
 
 import (
 	"context"
-	"fmt"
 	"github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/persistent"
 	"github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/shared"
+	"iter"
 	"sync/atomic"
 
 	"github.com/google/uuid"
@@ -36,6 +36,7 @@ type PersistentSubscription struct {
 	closed         *int32
 	cancel         context.CancelFunc
 	logger         *logger
+	telemetry      *telemetry
 }
 
 // Recv awaits for the next incoming persistent subscription event.
@@ -43,7 +44,7 @@ func (connection *PersistentSubscription) Recv() *PersistentSubscriptionEvent {
 	if atomic.LoadInt32(connection.closed) != 0 {
 		return &PersistentSubscriptionEvent{
 			SubscriptionDropped: &SubscriptionDropped{
-				Error: fmt.Errorf("subscription has been dropped"),
+				Error: errSubscriptionDropped,
 			},
 		}
 	}
@@ -52,7 +53,7 @@ func (connection *PersistentSubscription) Recv() *PersistentSubscriptionEvent {
 	if err != nil {
 		atomic.StoreInt32(connection.closed, 1)
 
-		connection.logger.error("subscription has dropped. Reason: %v", err)
+		connection.logger.withError(err).error("subscription has dropped")
 
 		dropped := SubscriptionDropped{
 			Error: err,
@@ -67,6 +68,7 @@ func (connection *PersistentSubscription) Recv() *PersistentSubscriptionEvent {
 	case *persistent.ReadResp_Event:
 		{
 			resolvedEvent, retryCount := fromPersistentProtoResponse(result)
+			connection.telemetry.recordSubscriptionLag("persistent", resolvedEvent)
 			return &PersistentSubscriptionEvent{
 				EventAppeared: &EventAppeared{
 					Event:      resolvedEvent,
@@ -79,6 +81,31 @@ func (connection *PersistentSubscription) Recv() *PersistentSubscriptionEvent {
 	panic("unreachable code")
 }
 
+// Events returns an iterator over the events of the persistent subscription. Events still need to be acknowledged
+// with Ack or Nack. The iteration ends when the subscription is closed, or after yielding the error that dropped the
+// subscription. Breaking out of the loop closes the subscription.
+func (connection *PersistentSubscription) Events() iter.Seq2[*EventAppeared, error] {
+	return func(yield func(*EventAppeared, error) bool) {
+		defer connection.Close()
+
+		for {
+			event := connection.Recv()
+
+			if event.SubscriptionDropped != nil {
+				if err := event.SubscriptionDropped.Error; !isClosedError(err) {
+					yield(nil, err)
+				}
+
+				return
+			}
+
+			if event.EventAppeared != nil && !yield(event.EventAppeared, nil) {
+				return
+			}
+		}
+	}
+}
+
 // Close drops the persistent subscription and free allocated resources.
 func (connection *PersistentSubscription) Close() error {
 	connection.once.Do(func() {
@@ -158,6 +185,7 @@ func newPersistentSubscription(
 	subscriptionId string,
 	cancel context.CancelFunc,
 	logger *logger,
+	telemetry *telemetry,
 ) *PersistentSubscription {
 	once := new(sync.Once)
 	closed := new(int32)
@@ -169,6 +197,7 @@ func newPersistentSubscription(
 		once:           once,
 		closed:         closed,
 		cancel:         cancel,
-		logger:         logger,
+		logger:         logger.with(LogKeySubscriptionId, subscriptionId),
+		telemetry:      telemetry,
 	}
 }
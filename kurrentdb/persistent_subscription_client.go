@@ -13,6 +13,7 @@ import (
 
 type persistentClient struct {
 	inner                        *grpcClient
+	credentials                  *callCredentials
 	persistentSubscriptionClient persistent.PersistentSubscriptionsClient
 }
 
@@ -27,7 +28,7 @@ func (client *persistentClient) ConnectToPersistentSubscription(
 ) (*PersistentSubscription, error) {
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.inner.perRPCCredentials)
+	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.credentials)
 	readClient, err := client.persistentSubscriptionClient.Read(ctx, callOptions...)
 	if err != nil {
 		defer cancel()
@@ -51,7 +52,7 @@ func (client *persistentClient) ConnectToPersistentSubscription(
 			asyncConnection := newPersistentSubscription(
 				readClient,
 				readResult.GetSubscriptionConfirmation().SubscriptionId,
-				cancel, client.inner.logger)
+				cancel, client.inner.logger, client.inner.telemetry)
 
 			return asyncConnection, nil
 		}
@@ -83,7 +84,7 @@ func (client *persistentClient) CreateStreamSubscription(
 
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.inner.perRPCCredentials)
+	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.credentials)
 	defer cancel()
 	_, err := client.persistentSubscriptionClient.Create(ctx, createSubscriptionConfig, callOptions...)
 	if err != nil {
@@ -118,7 +119,7 @@ func (client *persistentClient) CreateAllSubscription(
 
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.inner.perRPCCredentials)
+	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.credentials)
 	defer cancel()
 
 	_, err = client.persistentSubscriptionClient.Create(ctx, protoConfig, callOptions...)
@@ -142,7 +143,7 @@ func (client *persistentClient) UpdateStreamSubscription(
 	updateSubscriptionConfig := updatePersistentRequestStreamProto(streamName, groupName, position, settings)
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.inner.perRPCCredentials)
+	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.credentials)
 	defer cancel()
 
 	_, err := client.persistentSubscriptionClient.Update(ctx, updateSubscriptionConfig, callOptions...)
@@ -166,7 +167,7 @@ func (client *persistentClient) UpdateAllSubscription(
 
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.inner.perRPCCredentials)
+	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.credentials)
 	defer cancel()
 
 	_, err := client.persistentSubscriptionClient.Update(ctx, updateSubscriptionConfig, callOptions...)
@@ -188,7 +189,7 @@ func (client *persistentClient) DeleteStreamSubscription(
 	deleteSubscriptionOptions := deletePersistentRequestStreamProto(streamName, groupName)
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.inner.perRPCCredentials)
+	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.credentials)
 	defer cancel()
 
 	_, err := client.persistentSubscriptionClient.Delete(ctx, deleteSubscriptionOptions, callOptions...)
@@ -209,7 +210,7 @@ func (client *persistentClient) DeleteAllSubscription(
 	deleteSubscriptionOptions := deletePersistentRequestAllOptionsProto(groupName)
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.inner.perRPCCredentials)
+	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.credentials)
 	defer cancel()
 
 	_, err := client.persistentSubscriptionClient.Delete(ctx, deleteSubscriptionOptions, callOptions...)
@@ -257,7 +258,7 @@ func (client *persistentClient) listPersistentSubscriptions(
 
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.inner.perRPCCredentials)
+	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.credentials)
 
 	defer cancel()
 
@@ -305,7 +306,7 @@ func (client *persistentClient) getPersistentSubscriptionInfo(
 
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.inner.perRPCCredentials)
+	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.credentials)
 	defer cancel()
 
 	resp, err := client.persistentSubscriptionClient.GetInfo(ctx, getInfoReq, callOptions...)
@@ -354,7 +355,7 @@ func (client *persistentClient) replayParkedMessages(
 
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.inner.perRPCCredentials)
+	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.credentials)
 	defer cancel()
 
 	_, err := client.persistentSubscriptionClient.ReplayParked(ctx, replayReq, callOptions...)
@@ -374,7 +375,7 @@ func (client *persistentClient) restartSubsystem(
 ) error {
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.inner.perRPCCredentials)
+	callOptions, ctx, cancel := configureGrpcCall(parent, conf, options, callOptions, client.credentials)
 	defer cancel()
 
 	_, err := client.persistentSubscriptionClient.RestartSubsystem(ctx, &shared.Empty{}, callOptions...)
@@ -498,9 +499,10 @@ func subscriptionInfoFromWire(wire *persistent.SubscriptionInfo) (*PersistentSub
 	return &info, nil
 }
 
-func newPersistentClient(inner *grpcClient, client persistent.PersistentSubscriptionsClient) persistentClient {
+func newPersistentClient(inner *grpcClient, credentials *callCredentials, client persistent.PersistentSubscriptionsClient) persistentClient {
 	return persistentClient{
 		inner:                        inner,
+		credentials:                  credentials,
 		persistentSubscriptionClient: client,
 	}
 }
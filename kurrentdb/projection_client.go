@@ -43,146 +43,170 @@ func (client *ProjectionClient) Close() error {
 }
 
 func (client *ProjectionClient) Create(
-	context context.Context,
+	ctx context.Context,
 	name string,
 	query string,
 	opts CreateProjectionOptions,
 ) error {
-	opts.setDefaults()
-	handle, err := client.inner.grpcClient.getConnectionHandle()
-	if err != nil {
-		return err
-	}
+	return tracedErr(ctx, client.inner.grpcClient.telemetry, "projections.create", projectionAttributes(name), func(context context.Context) error {
+		opts.setDefaults()
+		context, cancelDeadline := withOperationDeadline(context, client.inner.config, &opts)
+		defer cancelDeadline()
 
-	projClient := projections.NewProjectionsClient(handle.Connection())
-	var headers, trailers metadata.MD
-	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.grpcClient.perRPCCredentials)
-	defer cancel()
+		handle, err := client.inner.grpcClient.getConnectionHandle(context)
+		if err != nil {
+			return err
+		}
 
-	_, err = projClient.Create(ctx, &projections.CreateReq{
-		Options: &projections.CreateReq_Options{
-			Query: query,
-			Mode: &projections.CreateReq_Options_Continuous_{
-				Continuous: &projections.CreateReq_Options_Continuous{
-					Name:                name,
-					EmitEnabled:         opts.Emit,
-					TrackEmittedStreams: opts.TrackEmittedStreams,
+		projClient := projections.NewProjectionsClient(handle.Connection())
+		var headers, trailers metadata.MD
+		callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
+		callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.credentials)
+		defer cancel()
+
+		_, err = projClient.Create(ctx, &projections.CreateReq{
+			Options: &projections.CreateReq_Options{
+				Query: query,
+				Mode: &projections.CreateReq_Options_Continuous_{
+					Continuous: &projections.CreateReq_Options_Continuous{
+						Name:                name,
+						EmitEnabled:         opts.Emit,
+						TrackEmittedStreams: opts.TrackEmittedStreams,
+					},
 				},
 			},
-		},
-	}, callOptions...)
+		}, callOptions...)
 
-	return err
+		return err
+	})
 }
 
 func (client *ProjectionClient) Update(
-	context context.Context,
+	ctx context.Context,
 	name string,
 	query string,
 	opts UpdateProjectionOptions,
 ) error {
-	opts.setDefaults()
-	handle, err := client.inner.grpcClient.getConnectionHandle()
-	if err != nil {
-		return err
-	}
+	return tracedErr(ctx, client.inner.grpcClient.telemetry, "projections.update", projectionAttributes(name), func(context context.Context) error {
+		opts.setDefaults()
+		context, cancelDeadline := withOperationDeadline(context, client.inner.config, &opts)
+		defer cancelDeadline()
 
-	projClient := projections.NewProjectionsClient(handle.Connection())
-	var headers, trailers metadata.MD
-	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.grpcClient.perRPCCredentials)
-	defer cancel()
+		handle, err := client.inner.grpcClient.getConnectionHandle(context)
+		if err != nil {
+			return err
+		}
 
-	options := &projections.UpdateReq_Options{
-		Name:  name,
-		Query: query,
-	}
+		projClient := projections.NewProjectionsClient(handle.Connection())
+		var headers, trailers metadata.MD
+		callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
+		callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.credentials)
+		defer cancel()
 
-	if opts.Emit == nil {
-		options.EmitOption = &projections.UpdateReq_Options_NoEmitOptions{}
-	} else {
-		options.EmitOption = &projections.UpdateReq_Options_EmitEnabled{
-			EmitEnabled: *opts.Emit,
+		options := &projections.UpdateReq_Options{
+			Name:  name,
+			Query: query,
 		}
-	}
 
-	_, err = projClient.Update(ctx, &projections.UpdateReq{
-		Options: options,
-	}, callOptions...)
+		if opts.Emit == nil {
+			options.EmitOption = &projections.UpdateReq_Options_NoEmitOptions{}
+		} else {
+			options.EmitOption = &projections.UpdateReq_Options_EmitEnabled{
+				EmitEnabled: *opts.Emit,
+			}
+		}
 
-	return err
+		_, err = projClient.Update(ctx, &projections.UpdateReq{
+			Options: options,
+		}, callOptions...)
+
+		return err
+	})
 }
 
 func (client *ProjectionClient) Delete(
-	context context.Context,
+	ctx context.Context,
 	name string,
 	opts DeleteProjectionOptions,
 ) error {
-	opts.setDefaults()
-	handle, err := client.inner.grpcClient.getConnectionHandle()
-	if err != nil {
-		return err
-	}
+	return tracedErr(ctx, client.inner.grpcClient.telemetry, "projections.delete", projectionAttributes(name), func(context context.Context) error {
+		opts.setDefaults()
+		context, cancelDeadline := withOperationDeadline(context, client.inner.config, &opts)
+		defer cancelDeadline()
 
-	projClient := projections.NewProjectionsClient(handle.Connection())
-	var headers, trailers metadata.MD
-	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.grpcClient.perRPCCredentials)
-	defer cancel()
+		handle, err := client.inner.grpcClient.getConnectionHandle(context)
+		if err != nil {
+			return err
+		}
 
-	_, err = projClient.Delete(ctx, &projections.DeleteReq{
-		Options: &projections.DeleteReq_Options{
-			Name:                   name,
-			DeleteEmittedStreams:   opts.DeleteEmittedStreams,
-			DeleteStateStream:      opts.DeleteStateStream,
-			DeleteCheckpointStream: opts.DeleteCheckpointStream,
-		},
-	}, callOptions...)
+		projClient := projections.NewProjectionsClient(handle.Connection())
+		var headers, trailers metadata.MD
+		callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
+		callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.credentials)
+		defer cancel()
+
+		_, err = projClient.Delete(ctx, &projections.DeleteReq{
+			Options: &projections.DeleteReq_Options{
+				Name:                   name,
+				DeleteEmittedStreams:   opts.DeleteEmittedStreams,
+				DeleteStateStream:      opts.DeleteStateStream,
+				DeleteCheckpointStream: opts.DeleteCheckpointStream,
+			},
+		}, callOptions...)
 
-	return err
+		return err
+	})
 }
 
 func (client *ProjectionClient) Enable(
-	context context.Context,
+	ctx context.Context,
 	name string,
 	opts GenericProjectionOptions,
 ) error {
-	opts.setDefaults()
-	handle, err := client.inner.grpcClient.getConnectionHandle()
-	if err != nil {
-		return err
-	}
+	return tracedErr(ctx, client.inner.grpcClient.telemetry, "projections.enable", projectionAttributes(name), func(context context.Context) error {
+		opts.setDefaults()
+		context, cancelDeadline := withOperationDeadline(context, client.inner.config, &opts)
+		defer cancelDeadline()
 
-	projClient := projections.NewProjectionsClient(handle.Connection())
-	var headers, trailers metadata.MD
-	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.grpcClient.perRPCCredentials)
-	defer cancel()
+		handle, err := client.inner.grpcClient.getConnectionHandle(context)
+		if err != nil {
+			return err
+		}
 
-	_, err = projClient.Enable(ctx, &projections.EnableReq{
-		Options: &projections.EnableReq_Options{
-			Name: name,
-		},
-	}, callOptions...)
+		projClient := projections.NewProjectionsClient(handle.Connection())
+		var headers, trailers metadata.MD
+		callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
+		callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.credentials)
+		defer cancel()
 
-	return err
+		_, err = projClient.Enable(ctx, &projections.EnableReq{
+			Options: &projections.EnableReq_Options{
+				Name: name,
+			},
+		}, callOptions...)
+
+		return err
+	})
 }
 
 func (client *ProjectionClient) Disable(
-	context context.Context,
+	ctx context.Context,
 	name string,
 	opts GenericProjectionOptions,
 ) error {
-	return client.disable(context, name, true, opts)
+	return tracedErr(ctx, client.inner.grpcClient.telemetry, "projections.disable", projectionAttributes(name), func(context context.Context) error {
+		return client.disable(context, name, true, opts)
+	})
 }
 
 func (client *ProjectionClient) Abort(
-	context context.Context,
+	ctx context.Context,
 	name string,
 	opts GenericProjectionOptions,
 ) error {
-	return client.disable(context, name, false, opts)
+	return tracedErr(ctx, client.inner.grpcClient.telemetry, "projections.abort", projectionAttributes(name), func(context context.Context) error {
+		return client.disable(context, name, false, opts)
+	})
 }
 
 func (client *ProjectionClient) disable(
@@ -192,7 +216,10 @@ func (client *ProjectionClient) disable(
 	opts GenericProjectionOptions,
 ) error {
 	opts.setDefaults()
-	handle, err := client.inner.grpcClient.getConnectionHandle()
+	context, cancelDeadline := withOperationDeadline(context, client.inner.config, &opts)
+	defer cancelDeadline()
+
+	handle, err := client.inner.grpcClient.getConnectionHandle(context)
 	if err != nil {
 		return err
 	}
@@ -200,7 +227,7 @@ func (client *ProjectionClient) disable(
 	projClient := projections.NewProjectionsClient(handle.Connection())
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.grpcClient.perRPCCredentials)
+	callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.credentials)
 	defer cancel()
 
 	_, err = projClient.Disable(ctx, &projections.DisableReq{
@@ -214,113 +241,133 @@ func (client *ProjectionClient) disable(
 }
 
 func (client *ProjectionClient) Reset(
-	context context.Context,
+	ctx context.Context,
 	name string,
 	opts ResetProjectionOptions,
 ) error {
-	opts.setDefaults()
-	handle, err := client.inner.grpcClient.getConnectionHandle()
-	if err != nil {
-		return err
-	}
+	return tracedErr(ctx, client.inner.grpcClient.telemetry, "projections.reset", projectionAttributes(name), func(context context.Context) error {
+		opts.setDefaults()
+		context, cancelDeadline := withOperationDeadline(context, client.inner.config, &opts)
+		defer cancelDeadline()
 
-	projClient := projections.NewProjectionsClient(handle.Connection())
-	var headers, trailers metadata.MD
-	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.grpcClient.perRPCCredentials)
-	defer cancel()
+		handle, err := client.inner.grpcClient.getConnectionHandle(context)
+		if err != nil {
+			return err
+		}
 
-	_, err = projClient.Reset(ctx, &projections.ResetReq{
-		Options: &projections.ResetReq_Options{
-			Name:            name,
-			WriteCheckpoint: opts.WriteCheckpoint,
-		},
-	}, callOptions...)
+		projClient := projections.NewProjectionsClient(handle.Connection())
+		var headers, trailers metadata.MD
+		callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
+		callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.credentials)
+		defer cancel()
 
-	return err
+		_, err = projClient.Reset(ctx, &projections.ResetReq{
+			Options: &projections.ResetReq_Options{
+				Name:            name,
+				WriteCheckpoint: opts.WriteCheckpoint,
+			},
+		}, callOptions...)
+
+		return err
+	})
 }
 
 func (client *ProjectionClient) GetResult(
-	context context.Context,
+	ctx context.Context,
 	name string,
 	opts GetResultProjectionOptions,
 ) (*structpb.Value, error) {
-	opts.setDefaults()
-	handle, err := client.inner.grpcClient.getConnectionHandle()
-	if err != nil {
-		return nil, err
-	}
+	return traced(ctx, client.inner.grpcClient.telemetry, "projections.get_result", projectionAttributes(name), func(context context.Context) (*structpb.Value, error) {
+		opts.setDefaults()
+		context, cancelDeadline := withOperationDeadline(context, client.inner.config, &opts)
+		defer cancelDeadline()
 
-	projClient := projections.NewProjectionsClient(handle.Connection())
-	var headers, trailers metadata.MD
-	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.grpcClient.perRPCCredentials)
-	defer cancel()
+		handle, err := client.inner.grpcClient.getConnectionHandle(context)
+		if err != nil {
+			return nil, err
+		}
 
-	resp, err := projClient.Result(ctx, &projections.ResultReq{
-		Options: &projections.ResultReq_Options{
-			Name:      name,
-			Partition: opts.Partition,
-		},
-	}, callOptions...)
+		projClient := projections.NewProjectionsClient(handle.Connection())
+		var headers, trailers metadata.MD
+		callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
+		callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.credentials)
+		defer cancel()
 
-	if err != nil {
-		return nil, err
-	}
+		resp, err := projClient.Result(ctx, &projections.ResultReq{
+			Options: &projections.ResultReq_Options{
+				Name:      name,
+				Partition: opts.Partition,
+			},
+		}, callOptions...)
 
-	return resp.Result, nil
+		if err != nil {
+			return nil, err
+		}
+
+		return resp.Result, nil
+	})
 }
 
 func (client *ProjectionClient) GetState(
-	context context.Context,
+	ctx context.Context,
 	name string,
 	opts GetStateProjectionOptions,
 ) (*structpb.Value, error) {
-	opts.setDefaults()
-	handle, err := client.inner.grpcClient.getConnectionHandle()
-	if err != nil {
-		return nil, err
-	}
+	return traced(ctx, client.inner.grpcClient.telemetry, "projections.get_state", projectionAttributes(name), func(context context.Context) (*structpb.Value, error) {
+		opts.setDefaults()
+		context, cancelDeadline := withOperationDeadline(context, client.inner.config, &opts)
+		defer cancelDeadline()
 
-	projClient := projections.NewProjectionsClient(handle.Connection())
-	var headers, trailers metadata.MD
-	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.grpcClient.perRPCCredentials)
-	defer cancel()
+		handle, err := client.inner.grpcClient.getConnectionHandle(context)
+		if err != nil {
+			return nil, err
+		}
 
-	resp, err := projClient.State(ctx, &projections.StateReq{
-		Options: &projections.StateReq_Options{
-			Name:      name,
-			Partition: opts.Partition,
-		},
-	}, callOptions...)
+		projClient := projections.NewProjectionsClient(handle.Connection())
+		var headers, trailers metadata.MD
+		callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
+		callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.credentials)
+		defer cancel()
 
-	if err != nil {
-		return nil, err
-	}
+		resp, err := projClient.State(ctx, &projections.StateReq{
+			Options: &projections.StateReq_Options{
+				Name:      name,
+				Partition: opts.Partition,
+			},
+		}, callOptions...)
+
+		if err != nil {
+			return nil, err
+		}
 
-	return resp.State, nil
+		return resp.State, nil
+	})
 }
 
 func (client *ProjectionClient) RestartSubsystem(
-	context context.Context,
+	ctx context.Context,
 	opts GenericProjectionOptions,
 ) error {
-	opts.setDefaults()
-	handle, err := client.inner.grpcClient.getConnectionHandle()
-	if err != nil {
-		return err
-	}
+	return tracedErr(ctx, client.inner.grpcClient.telemetry, "projections.restart_subsystem", nil, func(context context.Context) error {
+		opts.setDefaults()
+		context, cancelDeadline := withOperationDeadline(context, client.inner.config, &opts)
+		defer cancelDeadline()
 
-	projClient := projections.NewProjectionsClient(handle.Connection())
-	var headers, trailers metadata.MD
-	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.grpcClient.perRPCCredentials)
-	defer cancel()
+		handle, err := client.inner.grpcClient.getConnectionHandle(context)
+		if err != nil {
+			return err
+		}
 
-	_, err = projClient.RestartSubsystem(ctx, &shared.Empty{}, callOptions...)
+		projClient := projections.NewProjectionsClient(handle.Connection())
+		var headers, trailers metadata.MD
+		callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
+		callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.credentials)
+		defer cancel()
 
-	return err
+		_, err = projClient.RestartSubsystem(ctx, &shared.Empty{}, callOptions...)
+
+		return err
+	})
 }
 
 type ProjectionStatus struct {
@@ -376,31 +423,37 @@ func (projSelect projectionSelect) setProjectionMode(opts *projections.Statistic
 }
 
 func (client *ProjectionClient) GetStatus(
-	context context.Context,
+	ctx context.Context,
 	name string,
 	opts GenericProjectionOptions,
 ) (*ProjectionStatus, error) {
-	projs, err := client.listInternal(context, named{name: name}, opts)
+	return traced(ctx, client.inner.grpcClient.telemetry, "projections.get_status", projectionAttributes(name), func(context context.Context) (*ProjectionStatus, error) {
+		projs, err := client.listInternal(context, named{name: name}, opts)
 
-	if err != nil {
-		return nil, err
-	}
+		if err != nil {
+			return nil, err
+		}
 
-	return &projs[0], nil
+		return &projs[0], nil
+	})
 }
 
 func (client *ProjectionClient) ListContinuous(
-	context context.Context,
+	ctx context.Context,
 	opts GenericProjectionOptions,
 ) ([]ProjectionStatus, error) {
-	return client.listInternal(context, projectionSelectContinuous, opts)
+	return traced(ctx, client.inner.grpcClient.telemetry, "projections.list", nil, func(context context.Context) ([]ProjectionStatus, error) {
+		return client.listInternal(context, projectionSelectContinuous, opts)
+	})
 }
 
 func (client *ProjectionClient) ListAll(
-	context context.Context,
+	ctx context.Context,
 	opts GenericProjectionOptions,
 ) ([]ProjectionStatus, error) {
-	return client.listInternal(context, projectionSelectAll, opts)
+	return traced(ctx, client.inner.grpcClient.telemetry, "projections.list", nil, func(context context.Context) ([]ProjectionStatus, error) {
+		return client.listInternal(context, projectionSelectAll, opts)
+	})
 }
 
 func (client *ProjectionClient) listInternal(
@@ -409,7 +462,10 @@ func (client *ProjectionClient) listInternal(
 	opts GenericProjectionOptions,
 ) ([]ProjectionStatus, error) {
 	opts.setDefaults()
-	handle, err := client.inner.grpcClient.getConnectionHandle()
+	context, cancelDeadline := withOperationDeadline(context, client.inner.config, &opts)
+	defer cancelDeadline()
+
+	handle, err := client.inner.grpcClient.getConnectionHandle(context)
 	if err != nil {
 		return nil, err
 	}
@@ -417,7 +473,7 @@ func (client *ProjectionClient) listInternal(
 	projClient := projections.NewProjectionsClient(handle.Connection())
 	var headers, trailers metadata.MD
 	callOptions := []grpc.CallOption{grpc.Header(&headers), grpc.Trailer(&trailers)}
-	callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.grpcClient.perRPCCredentials)
+	callOptions, ctx, cancel := configureGrpcCall(context, client.inner.config, &opts, callOptions, client.inner.credentials)
 	defer cancel()
 
 	options := projections.StatisticsReq_Options{}
@@ -289,8 +289,8 @@ func toReadStreamRequest(streamID string, direction Direction, from StreamPositi
 	}
 }
 
-func toReadAllRequest(direction Direction, from AllPosition, count uint64, resolveLinks bool) *api.ReadReq {
-	return &api.ReadReq{
+func toReadAllRequest(direction Direction, from AllPosition, count uint64, resolveLinks bool, filterOptions *SubscriptionFilterOptions) (*api.ReadReq, error) {
+	readReq := &api.ReadReq{
 		Options: &api.ReadReq_Options{
 			CountOption: &api.ReadReq_Options_Count{
 				Count: count,
@@ -306,6 +306,16 @@ func toReadAllRequest(direction Direction, from AllPosition, count uint64, resol
 			},
 		},
 	}
+	if filterOptions != nil {
+		options, err := toFilterOptions(filterOptions)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to construct read request. Reason: %v", err)
+		}
+		readReq.Options.FilterOption = &api.ReadReq_Options_Filter{
+			Filter: options,
+		}
+	}
+	return readReq, nil
 }
 
 func toStreamSubscriptionRequest(streamID string, from StreamPosition, resolveLinks bool, filterOptions *SubscriptionFilterOptions) (*api.ReadReq, error) {
@@ -533,6 +543,14 @@ func toProtoUUID(id uuid.UUID) *shared.UUID {
 	}
 }
 
+func uuidFromProto(id *shared.UUID) (uuid.UUID, error) {
+	if obj := id.GetStructured(); obj != nil {
+		return ParseUUIDFromInt64(obj.MostSignificantBits, obj.LeastSignificantBits)
+	}
+
+	return uuid.Parse(id.GetString_())
+}
+
 func getContentTypeFromPersistentProto(recordedEvent *persistent.ReadResp_ReadEvent_RecordedEvent) string {
 	return recordedEvent.Metadata[systemMetadataKeysContentType]
 }
@@ -18,6 +18,9 @@ type ReadStreamOptions struct {
 	Deadline *time.Duration
 	// Requires the request to be performed by the leader of the cluster.
 	RequiresLeader bool
+	// Runs the operation on the node picked by this preference rather than the configuration's, keeping a separate
+	// connection to it. Only applies when connecting to a cluster. Empty uses the configuration's.
+	NodePreference NodePreference
 }
 
 func (o *ReadStreamOptions) kind() operationKind {
@@ -36,6 +39,10 @@ func (o *ReadStreamOptions) requiresLeader() bool {
 	return o.RequiresLeader
 }
 
+func (o *ReadStreamOptions) nodePreference() NodePreference {
+	return o.NodePreference
+}
+
 func (o *ReadStreamOptions) setDefaults() {
 	if o.From == nil {
 		o.From = Start{}
@@ -50,12 +57,21 @@ type ReadAllOptions struct {
 	From AllPosition
 	// Whether the read request should resolve linkTo events to their linked events.
 	ResolveLinkTos bool
+	// Max search window.
+	MaxSearchWindow int
+	// Checkpoint interval.
+	CheckpointInterval int
+	// Applies a server-side filter to determine if an event of the read should be yielded.
+	Filter *SubscriptionFilter
 	// Asks for authenticated request.
 	Authenticated *Credentials
 	// A length of time to use for gRPC deadlines.
 	Deadline *time.Duration
 	// Requires the request to be performed by the leader of the cluster.
 	RequiresLeader bool
+	// Runs the operation on the node picked by this preference rather than the configuration's, keeping a separate
+	// connection to it. Only applies when connecting to a cluster. Empty uses the configuration's.
+	NodePreference NodePreference
 }
 
 func (o *ReadAllOptions) kind() operationKind {
@@ -74,8 +90,21 @@ func (o *ReadAllOptions) requiresLeader() bool {
 	return o.RequiresLeader
 }
 
+func (o *ReadAllOptions) nodePreference() NodePreference {
+	return o.NodePreference
+}
+
 func (o *ReadAllOptions) setDefaults() {
 	if o.From == nil {
 		o.From = Start{}
 	}
+	if o.Filter != nil {
+		if o.MaxSearchWindow == 0 {
+			o.MaxSearchWindow = 32
+		}
+
+		if o.CheckpointInterval == 0 {
+			o.CheckpointInterval = 1
+		}
+	}
 }
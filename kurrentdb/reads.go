@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"sync"
 	"sync/atomic"
 
@@ -36,8 +37,31 @@ func (stream *ReadStream) Close() {
 	})
 }
 
-// Recv awaits for the next incoming event.
+// ReadMessage is a message received by a ReadStream, either an event or a checkpoint.
+type ReadMessage struct {
+	// When KurrentDB sends an event.
+	EventAppeared *ResolvedEvent
+	// When a checkpoint was created. Only filtered $all reads produce checkpoints.
+	CheckPointReached *Position
+}
+
+// Recv awaits for the next incoming event. Checkpoints are skipped, use RecvMessage to observe them.
 func (stream *ReadStream) Recv() (*ResolvedEvent, error) {
+	for {
+		msg, err := stream.RecvMessage()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if msg.EventAppeared != nil {
+			return msg.EventAppeared, nil
+		}
+	}
+}
+
+// RecvMessage awaits for the next incoming event or checkpoint.
+func (stream *ReadStream) RecvMessage() (*ReadMessage, error) {
 	if atomic.LoadInt32(stream.closed) != 0 {
 		return nil, io.EOF
 	}
@@ -57,7 +81,15 @@ func (stream *ReadStream) Recv() (*ResolvedEvent, error) {
 	switch msg.Content.(type) {
 	case *api.ReadResp_Event:
 		resolvedEvent := getResolvedEventFromProto(msg.GetEvent())
-		return &resolvedEvent, nil
+		return &ReadMessage{EventAppeared: &resolvedEvent}, nil
+	case *api.ReadResp_Checkpoint_:
+		checkpoint := msg.GetCheckpoint()
+		return &ReadMessage{
+			CheckPointReached: &Position{
+				Commit:  checkpoint.CommitPosition,
+				Prepare: checkpoint.PreparePosition,
+			},
+		}, nil
 	case *api.ReadResp_StreamNotFound_:
 		atomic.StoreInt32(stream.closed, 1)
 		streamName := string(msg.Content.(*api.ReadResp_StreamNotFound_).StreamNotFound.StreamIdentifier.StreamName)
@@ -67,6 +99,32 @@ func (stream *ReadStream) Recv() (*ResolvedEvent, error) {
 	panic("unreachable code")
 }
 
+// Events returns an iterator over the events of the read. Checkpoints are skipped. The iteration ends at the end of
+// the read, or after yielding the first error. The stream is closed once the iteration ends, including when breaking
+// out of the loop.
+func (stream *ReadStream) Events() iter.Seq2[*ResolvedEvent, error] {
+	return func(yield func(*ResolvedEvent, error) bool) {
+		defer stream.Close()
+
+		for {
+			event, err := stream.Recv()
+
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
 func newReadStream(params readStreamParams) *ReadStream {
 	once := new(sync.Once)
 	closed := new(int32)
@@ -23,3 +23,9 @@ func (resolved ResolvedEvent) OriginalEvent() *RecordedEvent {
 func (resolved ResolvedEvent) OriginalStreamRevision() StreamRevision {
 	return Revision(resolved.OriginalEvent().EventNumber)
 }
+
+// Decode deserializes the event with DefaultSerializer, the linked event if the original event is a resolved link. It
+// fails with ErrorCodeUnknownEventType if no type is registered for the event type.
+func (resolved ResolvedEvent) Decode() (any, error) {
+	return DefaultSerializer.Decode(&resolved)
+}
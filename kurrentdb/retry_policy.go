@@ -0,0 +1,169 @@
+package kurrentdb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetryPolicy describes how appends, deletes and persistent subscription management calls are retried after a
+// transient failure, typically the leader of the cluster changing. A request is only replayed when it is idempotent:
+// appends and deletes need an explicit StreamState other than Any, and every appended event needs an EventID.
+// Creating and deleting persistent subscriptions, replaying their parked messages and restarting the subsystem are only
+// retried after ErrorCodeNotLeader, which the server returns without applying them.
+type RetryPolicy struct {
+	// Maximum number of attempts, including the first one. Defaults to 3.
+	MaxAttempts int
+	// Delay before the first retry. Defaults to 100 milliseconds.
+	InitialBackoff time.Duration
+	// Upper bound of the delay between two attempts. Defaults to 2 seconds.
+	MaxBackoff time.Duration
+	// Error codes considered transient. Defaults to ErrorCodeNotLeader and ErrorUnavailable.
+	RetryableCodes []ErrorCode
+}
+
+func (p *RetryPolicy) setDefaults() {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 3
+	}
+
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = 100 * time.Millisecond
+	}
+
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = 2 * time.Second
+	}
+
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = p.InitialBackoff
+	}
+
+	if len(p.RetryableCodes) == 0 {
+		p.RetryableCodes = []ErrorCode{ErrorCodeNotLeader, ErrorUnavailable}
+	}
+}
+
+func (p *RetryPolicy) isRetryable(err error, attempt int) bool {
+	return attempt <= p.MaxAttempts && hasErrorCode(err, p.RetryableCodes)
+}
+
+// exponentialBackoff doubles the initial delay on every attempt, up to max.
+func exponentialBackoff(initial time.Duration, max time.Duration, attempt int) time.Duration {
+	delay := initial
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+
+	if delay > max {
+		delay = max
+	}
+
+	return delay
+}
+
+func hasErrorCode(err error, codes []ErrorCode) bool {
+	var esErr *Error
+	if !errors.As(err, &esErr) {
+		return false
+	}
+
+	for _, code := range codes {
+		if esErr.IsErrorCode(code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isIdempotentWrite tells if replaying a write cannot apply it twice.
+func isIdempotentWrite(state StreamState, events ...EventData) bool {
+	if _, ok := state.(Any); ok || state == nil {
+		return false
+	}
+
+	for _, event := range events {
+		if event.EventID == uuid.Nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// withRetry runs the operation until it succeeds, fails with a non-transient error, or the client's retry policy is
+// exhausted. Failed attempts enqueue a reconnection in the connection state machine before returning, so the next
+// attempt picks the reconnected handle up.
+func withRetry[T any](ctx context.Context, client *Client, idempotent bool, operation func() (T, error)) (T, error) {
+	if !idempotent {
+		return operation()
+	}
+
+	return retryOn(ctx, client, nil, operation)
+}
+
+// withRetryErr is withRetry for operations without result.
+func withRetryErr(ctx context.Context, client *Client, idempotent bool, operation func() error) error {
+	_, err := withRetry(ctx, client, idempotent, func() (struct{}, error) {
+		return struct{}{}, operation()
+	})
+
+	return err
+}
+
+// withNotLeaderRetryErr is withRetryErr for operations the server may have applied before failing with another
+// transient error, such as ErrorUnavailable. Only ErrorCodeNotLeader is retried, if the policy allows it, because a
+// follower rejects the call without applying it.
+func withNotLeaderRetryErr(ctx context.Context, client *Client, operation func() error) error {
+	_, err := retryOn(ctx, client, []ErrorCode{ErrorCodeNotLeader}, func() (struct{}, error) {
+		return struct{}{}, operation()
+	})
+
+	return err
+}
+
+// retryOn implements withRetry. When codes isn't nil, only the policy's retryable codes that are part of it are
+// retried.
+func retryOn[T any](ctx context.Context, client *Client, codes []ErrorCode, operation func() (T, error)) (T, error) {
+	result, err := operation()
+
+	if client.config.RetryPolicy == nil {
+		return result, err
+	}
+
+	policy := *client.config.RetryPolicy
+	policy.setDefaults()
+
+	if codes != nil {
+		var retryable []ErrorCode
+		for _, code := range policy.RetryableCodes {
+			if slices.Contains(codes, code) {
+				retryable = append(retryable, code)
+			}
+		}
+
+		policy.RetryableCodes = retryable
+	}
+
+	for attempt := 1; err != nil && policy.isRetryable(err, attempt+1); attempt++ {
+		delay := exponentialBackoff(policy.InitialBackoff, policy.MaxBackoff, attempt)
+		client.grpcClient.logger.withError(err).warn("operation failed, retrying",
+			LogKeyAttempt, attempt+1, "max_attempts", policy.MaxAttempts, "delay", delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, err
+		case <-timer.C:
+		}
+
+		result, err = operation()
+	}
+
+	return result, err
+}
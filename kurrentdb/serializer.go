@@ -0,0 +1,216 @@
+package kurrentdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec encodes and decodes event payloads.
+type Codec interface {
+	// ContentType returns the content type of the encoded payloads.
+	ContentType() ContentType
+	// Marshal encodes a value.
+	Marshal(value any) ([]byte, error)
+	// Unmarshal decodes a payload into the value pointed to by target.
+	Unmarshal(data []byte, target any) error
+}
+
+// JsonCodec encodes payloads with encoding/json.
+type JsonCodec struct{}
+
+// ContentType returns ContentTypeJson.
+func (JsonCodec) ContentType() ContentType {
+	return ContentTypeJson
+}
+
+// Marshal encodes a value as JSON.
+func (JsonCodec) Marshal(value any) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// Unmarshal decodes a JSON payload.
+func (JsonCodec) Unmarshal(data []byte, target any) error {
+	return json.Unmarshal(data, target)
+}
+
+// ProtobufCodec encodes payloads with the protobuf binary format. Values must implement proto.Message.
+type ProtobufCodec struct{}
+
+// ContentType returns ContentTypeBinary.
+func (ProtobufCodec) ContentType() ContentType {
+	return ContentTypeBinary
+}
+
+// Marshal encodes a protobuf message.
+func (ProtobufCodec) Marshal(value any) ([]byte, error) {
+	message, ok := value.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%T does not implement proto.Message", value)
+	}
+
+	return proto.Marshal(message)
+}
+
+// Unmarshal decodes a protobuf payload.
+func (ProtobufCodec) Unmarshal(data []byte, target any) error {
+	message, ok := target.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%T does not implement proto.Message", target)
+	}
+
+	return proto.Unmarshal(data, message)
+}
+
+// DefaultSerializer is used by clients without a configured Serializer and by ResolvedEvent.Decode.
+var DefaultSerializer = NewSerializer()
+
+// Serializer maps Go types to event types and converts events from and to their payload. A Serializer is safe for
+// concurrent use.
+type Serializer struct {
+	mu     sync.RWMutex
+	byType map[reflect.Type]*registeredEventType
+	byName map[string]*registeredEventType
+}
+
+type registeredEventType struct {
+	name   string
+	goType reflect.Type
+	codec  Codec
+}
+
+// NewSerializer creates an empty serializer.
+func NewSerializer() *Serializer {
+	return &Serializer{
+		byType: make(map[reflect.Type]*registeredEventType),
+		byName: make(map[string]*registeredEventType),
+	}
+}
+
+// RegisterEvent maps the Go type T to an event type name, using the given codec for its payload. Protobuf messages
+// are registered by pointer, e.g. RegisterEvent[*pb.OrderPlaced]. A nil codec defaults to JsonCodec. Registering a
+// type or a name twice fails.
+func RegisterEvent[T any](serializer *Serializer, eventType string, codec Codec) error {
+	goType := reflect.TypeFor[T]()
+
+	if codec == nil {
+		codec = JsonCodec{}
+	}
+
+	serializer.mu.Lock()
+	defer serializer.mu.Unlock()
+
+	if existing, ok := serializer.byType[goType]; ok {
+		return fmt.Errorf("type %v is already registered as event type '%s'", goType, existing.name)
+	}
+
+	if existing, ok := serializer.byName[eventType]; ok {
+		return fmt.Errorf("event type '%s' is already registered for type %v", eventType, existing.goType)
+	}
+
+	registered := &registeredEventType{
+		name:   eventType,
+		goType: goType,
+		codec:  codec,
+	}
+
+	serializer.byType[goType] = registered
+	serializer.byName[eventType] = registered
+
+	return nil
+}
+
+// EventType returns the event type name registered for the type of the given value.
+func (serializer *Serializer) EventType(value any) (string, error) {
+	registered, _, err := serializer.lookupType(value)
+	if err != nil {
+		return "", err
+	}
+
+	return registered.name, nil
+}
+
+// Serialize converts a value of a registered type, or a pointer to it, to an EventData with a new EventID.
+func (serializer *Serializer) Serialize(value any) (EventData, error) {
+	registered, value, err := serializer.lookupType(value)
+	if err != nil {
+		return EventData{}, err
+	}
+
+	data, err := registered.codec.Marshal(value)
+	if err != nil {
+		return EventData{}, fmt.Errorf("error when serializing event '%s': %w", registered.name, err)
+	}
+
+	return EventData{
+		EventID:     uuid.New(),
+		EventType:   registered.name,
+		ContentType: registered.codec.ContentType(),
+		Data:        data,
+	}, nil
+}
+
+// Deserialize converts a recorded event to a value of the type registered for its event type.
+func (serializer *Serializer) Deserialize(event *RecordedEvent) (any, error) {
+	serializer.mu.RLock()
+	registered, ok := serializer.byName[event.EventType]
+	serializer.mu.RUnlock()
+
+	if !ok {
+		return nil, &Error{code: ErrorCodeUnknownEventType, err: fmt.Errorf("no type is registered for event type '%s'", event.EventType)}
+	}
+
+	if registered.goType.Kind() == reflect.Pointer {
+		target := reflect.New(registered.goType.Elem())
+		if err := registered.codec.Unmarshal(event.Data, target.Interface()); err != nil {
+			return nil, &Error{code: ErrorCodeParsing, err: fmt.Errorf("error when deserializing event '%s': %w", event.EventType, err)}
+		}
+
+		return target.Interface(), nil
+	}
+
+	target := reflect.New(registered.goType)
+	if err := registered.codec.Unmarshal(event.Data, target.Interface()); err != nil {
+		return nil, &Error{code: ErrorCodeParsing, err: fmt.Errorf("error when deserializing event '%s': %w", event.EventType, err)}
+	}
+
+	return target.Elem().Interface(), nil
+}
+
+// Decode deserializes an event with the given serializer. When the event is a resolved link, the linked event is
+// decoded rather than the link.
+func (serializer *Serializer) Decode(event *ResolvedEvent) (any, error) {
+	if event.Event != nil {
+		return serializer.Deserialize(event.Event)
+	}
+
+	return serializer.Deserialize(event.Link)
+}
+
+// lookupType finds the registration of a value's type, dereferencing pointers to non-pointer registered types.
+func (serializer *Serializer) lookupType(value any) (*registeredEventType, any, error) {
+	serializer.mu.RLock()
+	defer serializer.mu.RUnlock()
+
+	goType := reflect.TypeOf(value)
+	if registered, ok := serializer.byType[goType]; ok {
+		return registered, value, nil
+	}
+
+	if goType != nil && goType.Kind() == reflect.Pointer {
+		if registered, ok := serializer.byType[goType.Elem()]; ok {
+			pointer := reflect.ValueOf(value)
+			if pointer.IsNil() {
+				return nil, nil, fmt.Errorf("cannot serialize a nil %v", goType)
+			}
+
+			return registered, pointer.Elem().Interface(), nil
+		}
+	}
+
+	return nil, nil, &Error{code: ErrorCodeUnknownEventType, err: fmt.Errorf("type %v is not registered", goType)}
+}
@@ -16,6 +16,11 @@ type SubscribeToStreamOptions struct {
 	Deadline *time.Duration
 	// Requires the request to be performed by the leader of the cluster.
 	RequiresLeader bool
+	// Runs the operation on the node picked by this preference rather than the configuration's, keeping a separate
+	// connection to it. Only applies when connecting to a cluster. Empty uses the configuration's.
+	NodePreference NodePreference
+	// Resubscribes automatically after a transient failure instead of dropping the subscription. Nil disables it.
+	Resubscribe *ResubscribeOptions
 }
 
 func (o *SubscribeToStreamOptions) kind() operationKind {
@@ -34,10 +39,20 @@ func (o *SubscribeToStreamOptions) requiresLeader() bool {
 	return o.RequiresLeader
 }
 
+func (o *SubscribeToStreamOptions) nodePreference() NodePreference {
+	return o.NodePreference
+}
+
 func (o *SubscribeToStreamOptions) setDefaults() {
 	if o.From == nil {
 		o.From = End{}
 	}
+
+	if o.Resubscribe != nil {
+		resubscribe := *o.Resubscribe
+		resubscribe.setDefaults()
+		o.Resubscribe = &resubscribe
+	}
 }
 
 // SubscribeToAllOptions options of the subscribe to $all request.
@@ -58,6 +73,11 @@ type SubscribeToAllOptions struct {
 	Deadline *time.Duration
 	// Requires the request to be performed by the leader of the cluster.
 	RequiresLeader bool
+	// Runs the operation on the node picked by this preference rather than the configuration's, keeping a separate
+	// connection to it. Only applies when connecting to a cluster. Empty uses the configuration's.
+	NodePreference NodePreference
+	// Resubscribes automatically after a transient failure instead of dropping the subscription. Nil disables it.
+	Resubscribe *ResubscribeOptions
 }
 
 func (o *SubscribeToAllOptions) kind() operationKind {
@@ -76,11 +96,21 @@ func (o *SubscribeToAllOptions) requiresLeader() bool {
 	return o.RequiresLeader
 }
 
+func (o *SubscribeToAllOptions) nodePreference() NodePreference {
+	return o.NodePreference
+}
+
 func (o *SubscribeToAllOptions) setDefaults() {
 	if o.From == nil {
 		o.From = End{}
 	}
 
+	if o.Resubscribe != nil {
+		resubscribe := *o.Resubscribe
+		resubscribe.setDefaults()
+		o.Resubscribe = &resubscribe
+	}
+
 	if o.Filter != nil {
 		if o.MaxSearchWindow == 0 {
 			o.MaxSearchWindow = 32
@@ -91,3 +121,46 @@ func (o *SubscribeToAllOptions) setDefaults() {
 		}
 	}
 }
+
+// ResubscribeOptions enables automatic resubscription of a catch-up subscription after a transient failure. The
+// subscription resumes right after the last event or checkpoint it delivered.
+type ResubscribeOptions struct {
+	// Maximum number of consecutive resubscription attempts. Zero means no limit.
+	MaxAttempts int
+	// Delay before the first resubscription attempt. Defaults to 100 milliseconds.
+	InitialBackoff time.Duration
+	// Upper bound of the delay between two resubscription attempts. Defaults to 5 seconds.
+	MaxBackoff time.Duration
+	// Error codes considered transient. Defaults to ErrorUnavailable and ErrorCodeNotLeader.
+	RetryableCodes []ErrorCode
+}
+
+func (o *ResubscribeOptions) setDefaults() {
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = 100 * time.Millisecond
+	}
+
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 5 * time.Second
+	}
+
+	if o.MaxBackoff < o.InitialBackoff {
+		o.MaxBackoff = o.InitialBackoff
+	}
+
+	if len(o.RetryableCodes) == 0 {
+		o.RetryableCodes = []ErrorCode{ErrorUnavailable, ErrorCodeNotLeader}
+	}
+}
+
+func (o *ResubscribeOptions) backoff(attempt int) time.Duration {
+	return exponentialBackoff(o.InitialBackoff, o.MaxBackoff, attempt)
+}
+
+func (o *ResubscribeOptions) isRetryable(err error, attempt int) bool {
+	if o.MaxAttempts > 0 && attempt > o.MaxAttempts {
+		return false
+	}
+
+	return hasErrorCode(err, o.RetryableCodes)
+}
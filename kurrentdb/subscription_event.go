@@ -16,6 +16,10 @@ type SubscriptionEvent struct {
 	CaughtUp *CaughtUp
 	// When an event has fallen behind
 	FellBehind *FellBehind
+	// When the subscription failed with a transient error and is about to resubscribe.
+	SubscriptionReconnecting *SubscriptionReconnecting
+	// When the subscription resubscribed successfully after a transient error.
+	SubscriptionReconnected *SubscriptionReconnected
 }
 
 type CaughtUp struct {
@@ -30,6 +34,24 @@ type FellBehind struct {
 	StreamRevision *uint64
 }
 
+// SubscriptionReconnecting when a subscription configured with ResubscribeOptions failed with a transient error.
+type SubscriptionReconnecting struct {
+	// Resubscription attempt number, starting at 1.
+	Attempt int
+	// How long the subscription waits before resubscribing.
+	Delay time.Duration
+	// Error that caused the resubscription.
+	Error error
+}
+
+// SubscriptionReconnected when a subscription configured with ResubscribeOptions resubscribed successfully.
+type SubscriptionReconnected struct {
+	// Resubscription attempt number that succeeded.
+	Attempt int
+	// Id of the new server-side subscription.
+	SubscriptionID string
+}
+
 // PersistentSubscriptionEvent used to handle persistent subscription notifications raised throughout its lifecycle.
 type PersistentSubscriptionEvent struct {
 	// When KurrentDB sends an event to the subscription.
@@ -0,0 +1,259 @@
+package kurrentdb
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+)
+
+// SubscriptionHandler processes an event delivered by RunSubscriptionToAll. Returning an error stops the runner.
+type SubscriptionHandler = func(ctx context.Context, event *ResolvedEvent) error
+
+// SubscriptionRunnerOptions options of RunSubscriptionToAll.
+type SubscriptionRunnerOptions struct {
+	// Options of the underlying $all subscription.
+	Subscription SubscribeToAllOptions
+	// Number of workers processing events concurrently. Defaults to 4.
+	Workers int
+	// Number of events each worker can buffer before the subscription is paused. Defaults to 64.
+	BufferSize int
+	// Called with the safe checkpoint each time it advances, never concurrently. All the events up to that position
+	// have been handled successfully, so it can be used as the starting point of a new subscription. Workers keep
+	// handling events during a call, only the latest of the checkpoints reached meanwhile is reported next.
+	OnCheckpoint func(position Position)
+}
+
+func (o *SubscriptionRunnerOptions) setDefaults() {
+	if o.Workers <= 0 {
+		o.Workers = 4
+	}
+
+	if o.BufferSize <= 0 {
+		o.BufferSize = 64
+	}
+}
+
+// RunSubscriptionToAll subscribes to $all and dispatches events to a pool of workers running the handler. Events are
+// partitioned by the stream id of their original event, so events of the same stream are handled one at a time and in
+// order, while events of different streams are handled concurrently.
+//
+// RunSubscriptionToAll blocks until the context is cancelled, in which case it returns nil, the handler fails, or the
+// subscription is dropped. Events already dispatched but not yet handled are skipped when stopping, as they are
+// past the safe checkpoint.
+func (client *Client) RunSubscriptionToAll(
+	parent context.Context,
+	opts SubscriptionRunnerOptions,
+	handler SubscriptionHandler,
+) error {
+	opts.setDefaults()
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	subscription, err := client.SubscribeToAll(ctx, opts.Subscription)
+	if err != nil {
+		return err
+	}
+	defer subscription.Close()
+
+	runner := newSubscriptionRunner(ctx, cancel, opts, handler)
+	err = runner.dispatch(subscription)
+	runner.stop()
+
+	if runner.failure != nil {
+		return runner.failure
+	}
+
+	if parent.Err() != nil {
+		return nil
+	}
+
+	return err
+}
+
+type subscriptionRunnerItem struct {
+	sequence uint64
+	event    *ResolvedEvent
+}
+
+type subscriptionRunner struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	handler SubscriptionHandler
+	queues  []chan subscriptionRunnerItem
+	tracker *checkpointTracker
+	wg      sync.WaitGroup
+	once    sync.Once
+	failure error
+}
+
+func newSubscriptionRunner(ctx context.Context, cancel context.CancelFunc, opts SubscriptionRunnerOptions, handler SubscriptionHandler) *subscriptionRunner {
+	runner := &subscriptionRunner{
+		ctx:     ctx,
+		cancel:  cancel,
+		handler: handler,
+		queues:  make([]chan subscriptionRunnerItem, opts.Workers),
+		tracker: newCheckpointTracker(opts.OnCheckpoint),
+	}
+
+	for i := range runner.queues {
+		runner.queues[i] = make(chan subscriptionRunnerItem, opts.BufferSize)
+		runner.wg.Add(1)
+		go runner.work(runner.queues[i])
+	}
+
+	return runner
+}
+
+func (runner *subscriptionRunner) dispatch(subscription *Subscription) error {
+	for {
+		event := subscription.Recv()
+
+		switch {
+		case event.SubscriptionDropped != nil:
+			if runner.ctx.Err() != nil {
+				return nil
+			}
+
+			return event.SubscriptionDropped.Error
+		case event.EventAppeared != nil:
+			original := event.EventAppeared.OriginalEvent()
+			item := subscriptionRunnerItem{
+				sequence: runner.tracker.track(original.Position),
+				event:    event.EventAppeared,
+			}
+
+			select {
+			case runner.queues[runner.partition(original.StreamID)] <- item:
+			case <-runner.ctx.Done():
+				return nil
+			}
+		case event.CheckPointReached != nil:
+			runner.tracker.complete(runner.tracker.track(*event.CheckPointReached))
+		}
+	}
+}
+
+func (runner *subscriptionRunner) partition(streamID string) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(streamID))
+
+	return int(hash.Sum32() % uint32(len(runner.queues)))
+}
+
+func (runner *subscriptionRunner) work(queue chan subscriptionRunnerItem) {
+	defer runner.wg.Done()
+
+	for item := range queue {
+		if runner.ctx.Err() != nil {
+			continue
+		}
+
+		if err := runner.handler(runner.ctx, item.event); err != nil {
+			runner.fail(err)
+			continue
+		}
+
+		runner.tracker.complete(item.sequence)
+	}
+}
+
+func (runner *subscriptionRunner) fail(err error) {
+	runner.once.Do(func() {
+		runner.failure = err
+		runner.cancel()
+	})
+}
+
+func (runner *subscriptionRunner) stop() {
+	for _, queue := range runner.queues {
+		close(queue)
+	}
+
+	runner.wg.Wait()
+}
+
+// checkpointTracker computes the highest position up to which every tracked item completed, regardless of the order
+// in which they complete.
+type checkpointTracker struct {
+	mu       sync.Mutex
+	head     uint64
+	tail     uint64
+	items    map[uint64]*trackedPosition
+	safe     Position
+	onUpdate func(position Position)
+	// notified is the head last reported to onUpdate, notifying whether a goroutine is reporting it.
+	notified  uint64
+	notifying bool
+}
+
+type trackedPosition struct {
+	position  Position
+	completed bool
+}
+
+func newCheckpointTracker(onUpdate func(position Position)) *checkpointTracker {
+	return &checkpointTracker{
+		items:    make(map[uint64]*trackedPosition),
+		onUpdate: onUpdate,
+	}
+}
+
+func (tracker *checkpointTracker) track(position Position) uint64 {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	sequence := tracker.tail
+	tracker.tail += 1
+	tracker.items[sequence] = &trackedPosition{position: position}
+
+	return sequence
+}
+
+func (tracker *checkpointTracker) complete(sequence uint64) {
+	tracker.mu.Lock()
+	if !tracker.advance(sequence) || tracker.onUpdate == nil || tracker.notifying {
+		tracker.mu.Unlock()
+		return
+	}
+
+	// onUpdate runs without the lock, so that a slow callback doesn't hold up the workers. The checkpoints reached in the
+	// meantime are left to this goroutine, which only reports the latest of them.
+	tracker.notifying = true
+	for tracker.notified < tracker.head {
+		tracker.notified = tracker.head
+		safe := tracker.safe
+
+		tracker.mu.Unlock()
+		tracker.onUpdate(safe)
+		tracker.mu.Lock()
+	}
+
+	tracker.notifying = false
+	tracker.mu.Unlock()
+}
+
+// advance marks an item as completed and moves the head past the completed items, returning whether it moved. Must be
+// called with the lock held.
+func (tracker *checkpointTracker) advance(sequence uint64) bool {
+	item, ok := tracker.items[sequence]
+	if !ok {
+		return false
+	}
+
+	item.completed = true
+
+	moved := false
+	for tracker.head < tracker.tail {
+		head := tracker.items[tracker.head]
+		if !head.completed {
+			break
+		}
+
+		tracker.safe = head.position
+		delete(tracker.items, tracker.head)
+		tracker.head += 1
+		moved = true
+	}
+
+	return moved
+}
@@ -2,42 +2,80 @@ package kurrentdb
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"iter"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	api "github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/streams"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+// errSubscriptionDropped is returned when receiving from a subscription that is already closed or dropped.
+var errSubscriptionDropped = errors.New("subscription has been dropped")
+
 // Subscription is a subscription's handle.
 type Subscription struct {
 	client *Client
-	id     string
-	inner  api.Streams_ReadClient
+	mu     sync.Mutex
+	stream *subscriptionStream
 	cancel context.CancelFunc
 	once   *sync.Once
 	closed *int32
+
+	resubscribe *resubscribeParams
+	checkpoint  subscriptionCheckpoint
+	attempt     int
+	pending     *SubscriptionReconnecting
+}
+
+// subscriptionStream is a single server-side subscription backing a Subscription.
+type subscriptionStream struct {
+	id       string
+	handle   *connectionHandle
+	inner    api.Streams_ReadClient
+	cancel   context.CancelFunc
+	trailers *metadata.MD
+}
+
+// subscriptionCheckpoint tracks the last position a subscription delivered, so it can be resumed from there.
+type subscriptionCheckpoint struct {
+	position *Position
+	revision *uint64
+}
+
+type resubscribeParams struct {
+	ctx     context.Context
+	options options
+	policy  ResubscribeOptions
+	request func(checkpoint subscriptionCheckpoint) (*api.ReadReq, error)
 }
 
-func newSubscription(client *Client, cancel context.CancelFunc, inner api.Streams_ReadClient, id string) *Subscription {
+func newSubscription(client *Client, cancel context.CancelFunc, stream *subscriptionStream, resubscribe *resubscribeParams) *Subscription {
 	once := new(sync.Once)
 	closed := new(int32)
 
 	atomic.StoreInt32(closed, 0)
 
 	return &Subscription{
-		client: client,
-		id:     id,
-		inner:  inner,
-		once:   once,
-		closed: closed,
-		cancel: cancel,
+		client:      client,
+		stream:      stream,
+		once:        once,
+		closed:      closed,
+		cancel:      cancel,
+		resubscribe: resubscribe,
 	}
 }
 
 // Id returns subscription's id.
 func (sub *Subscription) Id() string {
-	return sub.id
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	return sub.stream.id
 }
 
 // Close drops the subscription and cleans up allocated resources.
@@ -55,26 +93,28 @@ func (sub *Subscription) Recv() *SubscriptionEvent {
 	if atomic.LoadInt32(sub.closed) != 0 {
 		return &SubscriptionEvent{
 			SubscriptionDropped: &SubscriptionDropped{
-				Error: fmt.Errorf("subscription has been dropped"),
+				Error: errSubscriptionDropped,
 			},
 		}
 	}
 
 	for {
-		result, err := sub.inner.Recv()
-		if err != nil {
-			sub.client.grpcClient.logger.error("subscription has dropped. Reason: %v", err)
+		if sub.pending != nil {
+			return sub.reconnect()
+		}
 
-			dropped := SubscriptionDropped{
-				Error: err,
+		result, err := sub.stream.inner.Recv()
+		if err != nil {
+			if sub.resubscribe != nil && atomic.LoadInt32(sub.closed) == 0 {
+				err = sub.client.grpcClient.handleError(sub.stream.handle, *sub.stream.trailers, err)
+				return sub.retryOrDrop(err)
 			}
 
-			atomic.StoreInt32(sub.closed, 1)
-			return &SubscriptionEvent{
-				SubscriptionDropped: &dropped,
-			}
+			return sub.drop(err)
 		}
 
+		sub.attempt = 0
+
 		switch result.Content.(type) {
 		case *api.ReadResp_Checkpoint_:
 			{
@@ -84,6 +124,7 @@ func (sub *Subscription) Recv() *SubscriptionEvent {
 					Prepare: checkpoint.PreparePosition,
 				}
 
+				sub.checkpoint.position = &position
 				return &SubscriptionEvent{
 					CheckPointReached: &position,
 				}
@@ -91,6 +132,8 @@ func (sub *Subscription) Recv() *SubscriptionEvent {
 		case *api.ReadResp_Event:
 			{
 				resolvedEvent := getResolvedEventFromProto(result.GetEvent())
+				sub.track(&resolvedEvent)
+				sub.client.grpcClient.telemetry.recordSubscriptionLag("catch_up", &resolvedEvent)
 				return &SubscriptionEvent{
 					EventAppeared: &resolvedEvent,
 				}
@@ -146,6 +189,128 @@ func (sub *Subscription) Recv() *SubscriptionEvent {
 			}
 		}
 
-		sub.client.config.applyLogger(LogWarn, "received unknown message, skipping")
+		sub.client.grpcClient.logger.warn("received unknown message, skipping", LogKeySubscriptionId, sub.Id())
+	}
+}
+
+// Events returns an iterator over the events of the subscription. Checkpoints and state changes are skipped. The
+// iteration ends when the subscription is closed, or after yielding the error that dropped the subscription. Breaking
+// out of the loop closes the subscription.
+func (sub *Subscription) Events() iter.Seq2[*ResolvedEvent, error] {
+	return subscriptionEvents(sub.Recv, sub.Close)
+}
+
+func subscriptionEvents(recv func() *SubscriptionEvent, close func() error) iter.Seq2[*ResolvedEvent, error] {
+	return func(yield func(*ResolvedEvent, error) bool) {
+		defer close()
+
+		for {
+			event := recv()
+
+			if event.SubscriptionDropped != nil {
+				if err := event.SubscriptionDropped.Error; !isClosedError(err) {
+					yield(nil, err)
+				}
+
+				return
+			}
+
+			if event.EventAppeared != nil && !yield(event.EventAppeared, nil) {
+				return
+			}
+		}
+	}
+}
+
+// isClosedError tells if an error is the result of the subscription being closed or its context being cancelled.
+func isClosedError(err error) bool {
+	return errors.Is(err, errSubscriptionDropped) || errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled
+}
+
+func (sub *Subscription) track(event *ResolvedEvent) {
+	if sub.resubscribe == nil || event.OriginalEvent() == nil {
+		return
+	}
+
+	original := event.OriginalEvent()
+	position := original.Position
+	revision := original.EventNumber
+
+	sub.checkpoint.position = &position
+	sub.checkpoint.revision = &revision
+}
+
+func (sub *Subscription) drop(err error) *SubscriptionEvent {
+	sub.client.grpcClient.logger.withError(err).error("subscription has dropped", LogKeySubscriptionId, sub.Id())
+
+	dropped := SubscriptionDropped{
+		Error: err,
+	}
+
+	atomic.StoreInt32(sub.closed, 1)
+	sub.cancel()
+	return &SubscriptionEvent{
+		SubscriptionDropped: &dropped,
+	}
+}
+
+// retryOrDrop schedules a resubscription if the error is transient, otherwise drops the subscription.
+func (sub *Subscription) retryOrDrop(err error) *SubscriptionEvent {
+	if atomic.LoadInt32(sub.closed) != 0 || !sub.resubscribe.policy.isRetryable(err, sub.attempt+1) {
+		return sub.drop(err)
+	}
+
+	sub.attempt += 1
+	sub.pending = &SubscriptionReconnecting{
+		Attempt: sub.attempt,
+		Delay:   sub.resubscribe.policy.backoff(sub.attempt),
+		Error:   err,
+	}
+
+	sub.client.grpcClient.logger.withError(err).warn("subscription failed, resubscribing",
+		LogKeySubscriptionId, sub.Id(), LogKeyAttempt, sub.attempt, "delay", sub.pending.Delay)
+
+	return &SubscriptionEvent{
+		SubscriptionReconnecting: sub.pending,
+	}
+}
+
+// reconnect waits for the pending backoff delay then re-issues the subscription request from the last tracked
+// checkpoint.
+func (sub *Subscription) reconnect() *SubscriptionEvent {
+	pending := sub.pending
+	sub.pending = nil
+
+	timer := time.NewTimer(pending.Delay)
+	select {
+	case <-sub.resubscribe.ctx.Done():
+		timer.Stop()
+		return sub.drop(sub.resubscribe.ctx.Err())
+	case <-timer.C:
+	}
+
+	request, err := sub.resubscribe.request(sub.checkpoint)
+	if err != nil {
+		return sub.drop(err)
+	}
+
+	stream, err := sub.client.openSubscription(sub.resubscribe.ctx, sub.resubscribe.options, request)
+	if err != nil {
+		return sub.retryOrDrop(err)
+	}
+
+	sub.mu.Lock()
+	previous := sub.stream
+	sub.stream = stream
+	sub.mu.Unlock()
+	previous.cancel()
+
+	sub.client.grpcClient.logger.info("subscription resubscribed", LogKeySubscriptionId, stream.id, LogKeyAttempt, pending.Attempt)
+
+	return &SubscriptionEvent{
+		SubscriptionReconnected: &SubscriptionReconnected{
+			Attempt:        pending.Attempt,
+			SubscriptionID: stream.id,
+		},
 	}
 }
@@ -0,0 +1,333 @@
+package kurrentdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricNoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	traceNoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+const instrumentationName = "github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+
+// Metadata keys holding the trace context of the span that appended an event.
+const (
+	TraceIdMetadataKey = "$trace-id"
+	SpanIdMetadataKey  = "$span-id"
+)
+
+// Span attributes set by the client.
+const (
+	attributeSystem           = attribute.Key("db.system.name")
+	attributeOperation        = attribute.Key("db.operation.name")
+	attributeStream           = attribute.Key("db.kurrentdb.stream")
+	attributeExpectedState    = attribute.Key("db.kurrentdb.expected_state")
+	attributeRevision         = attribute.Key("db.kurrentdb.revision")
+	attributePosition         = attribute.Key("db.kurrentdb.position")
+	attributeErrorCode        = attribute.Key("db.kurrentdb.error_code")
+	attributeGroup            = attribute.Key("db.kurrentdb.group")
+	attributeProjection       = attribute.Key("db.kurrentdb.projection")
+	attributeSubscriptionKind = attribute.Key("db.kurrentdb.subscription.kind")
+)
+
+// telemetry holds the instruments shared by a client and its connection.
+type telemetry struct {
+	tracer           trace.Tracer
+	appendDuration   metric.Float64Histogram
+	subscriptionLag  metric.Float64Histogram
+	reconnectCounter metric.Int64Counter
+}
+
+func newTelemetry(conf *Configuration) *telemetry {
+	var tracerProvider trace.TracerProvider = traceNoop.NewTracerProvider()
+	if conf.TracerProvider != nil {
+		tracerProvider = conf.TracerProvider
+	}
+
+	var meterProvider metric.MeterProvider = metricNoop.NewMeterProvider()
+	if conf.MeterProvider != nil {
+		meterProvider = conf.MeterProvider
+	}
+
+	meter := meterProvider.Meter(instrumentationName)
+	t := &telemetry{tracer: tracerProvider.Tracer(instrumentationName)}
+
+	// Instrument creation only fails on invalid names, falling back to no-op instruments keeps the client usable.
+	var err error
+	t.appendDuration, err = meter.Float64Histogram(
+		"kurrentdb.client.append.duration",
+		metric.WithDescription("Duration of append operations."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		t.appendDuration, _ = metricNoop.Meter{}.Float64Histogram("kurrentdb.client.append.duration")
+	}
+
+	t.subscriptionLag, err = meter.Float64Histogram(
+		"kurrentdb.client.subscription.lag",
+		metric.WithDescription("Time between the creation of an event and its delivery to a subscription."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		t.subscriptionLag, _ = metricNoop.Meter{}.Float64Histogram("kurrentdb.client.subscription.lag")
+	}
+
+	t.reconnectCounter, err = meter.Int64Counter(
+		"kurrentdb.client.reconnects",
+		metric.WithDescription("Number of times the client reconnected to a node."),
+	)
+	if err != nil {
+		t.reconnectCounter, _ = metricNoop.Meter{}.Int64Counter("kurrentdb.client.reconnects")
+	}
+
+	return t
+}
+
+// spanResult is implemented by operation results carrying attributes worth recording on their span.
+type spanResult interface {
+	spanAttributes() []attribute.KeyValue
+}
+
+func (r *WriteResult) spanAttributes() []attribute.KeyValue {
+	if r == nil {
+		return nil
+	}
+
+	return []attribute.KeyValue{
+		attributeRevision.Int64(int64(r.NextExpectedVersion)),
+		attributePosition.Int64(int64(r.CommitPosition)),
+	}
+}
+
+func (r *DeleteResult) spanAttributes() []attribute.KeyValue {
+	if r == nil {
+		return nil
+	}
+
+	return []attribute.KeyValue{attributePosition.Int64(int64(r.Position.Commit))}
+}
+
+func (r *AppendRecordsResponse) spanAttributes() []attribute.KeyValue {
+	if r == nil {
+		return nil
+	}
+
+	return []attribute.KeyValue{attributePosition.Int64(r.Position)}
+}
+
+func (r *MultiStreamAppendResponse) spanAttributes() []attribute.KeyValue {
+	if r == nil {
+		return nil
+	}
+
+	return []attribute.KeyValue{attributePosition.Int64(r.Position)}
+}
+
+// traced runs an operation within a client span named after it. Errors are recorded on the span with their
+// ErrorCode, results implementing spanResult add their own attributes.
+func traced[T any](
+	ctx context.Context,
+	t *telemetry,
+	operation string,
+	attributes []attribute.KeyValue,
+	run func(context.Context) (T, error),
+) (T, error) {
+	ctx, span := t.tracer.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributeSystem.String("kurrentdb"), attributeOperation.String(operation)),
+		trace.WithAttributes(attributes...),
+	)
+	defer span.End()
+
+	result, err := run(ctx)
+	if err != nil {
+		recordSpanError(span, err)
+		return result, err
+	}
+
+	if withAttributes, ok := any(result).(spanResult); ok {
+		span.SetAttributes(withAttributes.spanAttributes()...)
+	}
+
+	return result, nil
+}
+
+// tracedErr is traced for operations without result.
+func tracedErr(ctx context.Context, t *telemetry, operation string, attributes []attribute.KeyValue, run func(context.Context) error) error {
+	_, err := traced(ctx, t, operation, attributes, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, run(ctx)
+	})
+
+	return err
+}
+
+func recordSpanError(span trace.Span, err error) {
+	var esErr *Error
+	if errors.As(err, &esErr) {
+		span.SetAttributes(attributeErrorCode.Int(int(esErr.Code())))
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// streamAttributes describes the stream targeted by an operation.
+func streamAttributes(streamID string, state StreamState) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{attributeStream.String(streamID)}
+	if state != nil {
+		attributes = append(attributes, attributeExpectedState.String(streamStateName(state)))
+	}
+
+	return attributes
+}
+
+// persistentAttributes describes the persistent subscription targeted by an operation.
+func persistentAttributes(streamName string, groupName string) []attribute.KeyValue {
+	return []attribute.KeyValue{attributeStream.String(streamName), attributeGroup.String(groupName)}
+}
+
+// projectionAttributes describes the projection targeted by an operation.
+func projectionAttributes(name string) []attribute.KeyValue {
+	return []attribute.KeyValue{attributeProjection.String(name)}
+}
+
+func streamStateName(state StreamState) string {
+	switch value := state.(type) {
+	case Any:
+		return "any"
+	case NoStream:
+		return "no_stream"
+	case StreamExists:
+		return "stream_exists"
+	case StreamRevision:
+		return strconv.FormatUint(value.Value, 10)
+	default:
+		return strconv.FormatInt(state.toRawInt64(), 10)
+	}
+}
+
+func (t *telemetry) recordAppend(ctx context.Context, start time.Time, err error) {
+	attributes := []attribute.KeyValue{attributeSystem.String("kurrentdb")}
+
+	var esErr *Error
+	if errors.As(err, &esErr) {
+		attributes = append(attributes, attributeErrorCode.Int(int(esErr.Code())))
+	}
+
+	t.appendDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attributes...))
+}
+
+// recordSubscriptionLag records how late an event is delivered. Subscriptions are told apart by kind only, as their
+// ids would make the metric's cardinality unbounded.
+func (t *telemetry) recordSubscriptionLag(kind string, event *ResolvedEvent) {
+	original := event.OriginalEvent()
+	if original == nil || original.CreatedDate.IsZero() {
+		return
+	}
+
+	t.subscriptionLag.Record(context.Background(), time.Since(original.CreatedDate).Seconds(),
+		metric.WithAttributes(attributeSubscriptionKind.String(kind)))
+}
+
+func (t *telemetry) recordReconnect(reason string) {
+	t.reconnectCounter.Add(context.Background(), 1, metric.WithAttributes(attribute.String("reason", reason)))
+}
+
+// traceMetadata returns the trace context of the span in ctx as metadata properties, or nil if there is no span or
+// tracing is not configured.
+func traceMetadata(ctx context.Context, conf *Configuration) map[string]string {
+	if conf.TracerProvider == nil {
+		return nil
+	}
+
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+
+	return map[string]string{
+		TraceIdMetadataKey: spanContext.TraceID().String(),
+		SpanIdMetadataKey:  spanContext.SpanID().String(),
+	}
+}
+
+// injectTraceContext returns copies of the events whose metadata carries the trace context of the span in ctx, when
+// tracing is configured. The values of the other metadata properties are copied as encoded, so numbers keep their
+// precision, but the object is re-encoded: its keys are sorted and insignificant whitespace is dropped. The metadata of
+// an event is left untouched if it is not a JSON object.
+func injectTraceContext(ctx context.Context, conf *Configuration, events []EventData) []EventData {
+	properties := traceMetadata(ctx, conf)
+	if properties == nil {
+		return events
+	}
+
+	injected := make([]EventData, len(events))
+	for i, event := range events {
+		injected[i] = event
+
+		metadata := make(map[string]json.RawMessage)
+		if len(event.Metadata) > 0 {
+			if err := json.Unmarshal(event.Metadata, &metadata); err != nil || metadata == nil {
+				continue
+			}
+		}
+
+		for key, value := range properties {
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				continue
+			}
+
+			metadata[key] = encoded
+		}
+
+		if data, err := json.Marshal(metadata); err == nil {
+			injected[i].Metadata = data
+		}
+	}
+
+	return injected
+}
+
+// ExtractTraceContext returns a copy of ctx carrying, as remote span context, the trace context stored in the metadata
+// of an event appended within a span. Spans started from the returned context belong to the trace of the append. ctx
+// is returned unchanged if the event has no trace context.
+func ExtractTraceContext(ctx context.Context, event *RecordedEvent) context.Context {
+	if event == nil || len(event.UserMetadata) == 0 {
+		return ctx
+	}
+
+	var metadata map[string]any
+	if err := json.Unmarshal(event.UserMetadata, &metadata); err != nil {
+		return ctx
+	}
+
+	traceIdValue, _ := metadata[TraceIdMetadataKey].(string)
+	spanIdValue, _ := metadata[SpanIdMetadataKey].(string)
+
+	traceId, err := trace.TraceIDFromHex(traceIdValue)
+	if err != nil {
+		return ctx
+	}
+
+	spanId, err := trace.SpanIDFromHex(spanIdValue)
+	if err != nil {
+		return ctx
+	}
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceId,
+		SpanID:     spanId,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+
+	return trace.ContextWithRemoteSpanContext(ctx, spanContext)
+}
@@ -0,0 +1,186 @@
+package kurrentdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	url2 "net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Token is an access token sent in the authorization header of calls.
+type Token struct {
+	// The access token, such as a JWT.
+	AccessToken string
+	// The authorization scheme the token is sent with. Defaults to Bearer.
+	TokenType string
+	// When the token expires. The zero value means never.
+	Expiry time.Time
+}
+
+func (token *Token) authorization() string {
+	tokenType := token.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+
+	return tokenType + " " + token.AccessToken
+}
+
+// TokenSource supplies the access token of a call when it is made. Implementations are called concurrently and are
+// expected to cache tokens until they are about to expire.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+type staticTokenSource struct {
+	token *Token
+}
+
+// StaticTokenSource returns a TokenSource always supplying the same bearer token, such as a long-lived JWT.
+func StaticTokenSource(accessToken string) TokenSource {
+	return &staticTokenSource{token: &Token{AccessToken: accessToken}}
+}
+
+func (source *staticTokenSource) Token(context.Context) (*Token, error) {
+	return source.token, nil
+}
+
+// ClientCredentials configures a TokenSource getting tokens from an OAuth2 authorization server with the client
+// credentials grant.
+type ClientCredentials struct {
+	// The token endpoint of the authorization server.
+	TokenURL string
+	// The client identifier, sent with its secret in a basic authorization header.
+	ClientID string
+	// The client secret.
+	ClientSecret string
+	// The scopes requested. Empty lets the authorization server pick.
+	Scopes []string
+	// Additional parameters of token requests, such as audience.
+	EndpointParams url2.Values
+	// Sends token requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+	// How long before it expires a token is refreshed, capped to half its lifetime. Defaults to 1 minute.
+	RefreshBefore time.Duration
+}
+
+const defaultTokenRefreshBefore = time.Minute
+
+type clientCredentialsTokenSource struct {
+	config    ClientCredentials
+	mu        sync.Mutex
+	token     *Token
+	refreshAt time.Time
+}
+
+// NewClientCredentialsTokenSource returns a TokenSource getting tokens with the OAuth2 client credentials grant. A
+// token is reused until it is about to expire. If refreshing it fails while it is still valid, it keeps being used and
+// the next call tries again.
+func NewClientCredentialsTokenSource(config ClientCredentials) TokenSource {
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+
+	if config.RefreshBefore <= 0 {
+		config.RefreshBefore = defaultTokenRefreshBefore
+	}
+
+	return &clientCredentialsTokenSource{config: config}
+}
+
+func (source *clientCredentialsTokenSource) Token(ctx context.Context) (*Token, error) {
+	source.mu.Lock()
+	defer source.mu.Unlock()
+
+	now := time.Now()
+	if source.token != nil && (source.refreshAt.IsZero() || now.Before(source.refreshAt)) {
+		return source.token, nil
+	}
+
+	token, lifetime, err := source.fetch(ctx)
+	if err != nil {
+		if source.token != nil && now.Before(source.token.Expiry) {
+			return source.token, nil
+		}
+
+		return nil, err
+	}
+
+	source.token = token
+	source.refreshAt = time.Time{}
+	if lifetime > 0 {
+		source.refreshAt = now.Add(lifetime - min(source.config.RefreshBefore, lifetime/2))
+	}
+
+	return token, nil
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (source *clientCredentialsTokenSource) fetch(ctx context.Context) (*Token, time.Duration, error) {
+	form := url2.Values{"grant_type": {"client_credentials"}}
+	for key, values := range source.config.EndpointParams {
+		form[key] = values
+	}
+
+	if len(source.config.Scopes) > 0 {
+		form.Set("scope", strings.Join(source.config.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, source.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create token request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url2.QueryEscape(source.config.ClientID), url2.QueryEscape(source.config.ClientSecret))
+
+	resp, err := source.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to request token: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	var response tokenResponse
+	if err := json.Unmarshal(body, &response); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, 0, fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || response.Error != "" {
+		if response.Error != "" {
+			return nil, 0, fmt.Errorf("token request failed with status %d: %s %s", resp.StatusCode, response.Error, response.ErrorDescription)
+		}
+
+		return nil, 0, fmt.Errorf("token request failed with status %d", resp.StatusCode)
+	}
+
+	if response.AccessToken == "" {
+		return nil, 0, fmt.Errorf("token response has no access token")
+	}
+
+	token := &Token{AccessToken: response.AccessToken, TokenType: response.TokenType}
+	lifetime := time.Duration(response.ExpiresIn) * time.Second
+	if lifetime > 0 {
+		token.Expiry = time.Now().Add(lifetime)
+	}
+
+	return token, lifetime, nil
+}
@@ -0,0 +1,154 @@
+package kurrentdb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+
+	gossipApi "github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/gossip"
+	"github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/shared"
+)
+
+// connectionDrain counts the in-flight RPCs of a gRPC connection, so that a connection replaced by a better node is
+// only closed once the operations and subscriptions started on it are done.
+type connectionDrain struct {
+	conn    *grpc.ClientConn
+	active  int64
+	retired int32
+	once    sync.Once
+}
+
+func (drain *connectionDrain) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (drain *connectionDrain) HandleRPC(_ context.Context, rpcStats stats.RPCStats) {
+	switch rpcStats.(type) {
+	case *stats.Begin:
+		atomic.AddInt64(&drain.active, 1)
+	case *stats.End:
+		if atomic.AddInt64(&drain.active, -1) == 0 && atomic.LoadInt32(&drain.retired) != 0 {
+			drain.close()
+		}
+	}
+}
+
+func (drain *connectionDrain) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (drain *connectionDrain) HandleConn(context.Context, stats.ConnStats) {}
+
+// retire closes the connection as soon as it has no in-flight RPC.
+func (drain *connectionDrain) retire() {
+	atomic.StoreInt32(&drain.retired, 1)
+
+	if atomic.LoadInt64(&drain.active) == 0 {
+		drain.close()
+	}
+}
+
+func (drain *connectionDrain) close() {
+	drain.once.Do(func() {
+		// Closing the connection from a stats callback would block the RPC reporting its end.
+		go func() {
+			_ = drain.conn.Close()
+		}()
+	})
+}
+
+// topologyRefresh is the outcome of a gossip read that found a better node than the current one, to which the
+// client is already connected.
+type topologyRefresh struct {
+	correlation uuid.UUID
+	connection  *grpc.ClientConn
+	serverInfo  *serverInfo
+	node        *discoveredNode
+}
+
+// isClusterConnection tells if the configuration targets a cluster, whose topology is known through gossip.
+func isClusterConnection(conf *Configuration) bool {
+	return conf.DnsDiscover || len(conf.GossipSeeds) > 0
+}
+
+// refreshTopology reads gossip from the current node and connects to the best candidate if NodePreference now favours
+// another node. It returns nil when the current node is still a best candidate or when the refresh failed.
+func refreshTopology(conf *Configuration, logger *logger, correlation uuid.UUID, conn *grpc.ClientConn, current EndPoint) *topologyRefresh {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(conf.GossipTimeout)*time.Second)
+	info, err := gossipApi.NewGossipClient(conn).Read(ctx, &shared.Empty{})
+	cancel()
+
+	if err != nil {
+		logger.withError(err).warn("error when refreshing gossip", LogKeyConnectionId, correlation)
+		return nil
+	}
+
+	info.Members = shuffleMembers(info.Members)
+	selected, err := pickBestCandidate(info, conf.NodePreference)
+	if err != nil {
+		logger.withError(err).warn("error when picking best candidate out of refreshed gossip", LogKeyConnectionId, correlation)
+		return nil
+	}
+
+	if isPreferredMember(info, selected, current, conf.NodePreference) {
+		return nil
+	}
+
+	selectedAddress := fmt.Sprintf("%s:%d", selected.GetHttpEndPoint().GetAddress(), selected.GetHttpEndPoint().GetPort())
+	nodeLogger := logger.with(LogKeyEndpoint, selectedAddress)
+	nodeLogger.info("better candidate found, migrating", "state", selected.State.String(), LogKeyConnectionId, correlation)
+
+	connection, drain, err := createGrpcConnection(conf, selectedAddress)
+	if err != nil {
+		nodeLogger.withError(err).warn("error when creating gRPC connection for the better candidate")
+		return nil
+	}
+
+	serverInfo, err := getSupportedMethods(context.Background(), conf, connection)
+	if err != nil {
+		nodeLogger.withError(err).warn("error when reading server features from the better candidate")
+		_ = connection.Close()
+		return nil
+	}
+
+	node := newDiscoveredNode(selectedAddress, nodeStateFromProto(selected.State))
+	node.drain = drain
+
+	return &topologyRefresh{
+		correlation: correlation,
+		connection:  connection,
+		serverInfo:  serverInfo,
+		node:        node,
+	}
+}
+
+// isPreferredMember tells if the current node ranks as well as the selected one, in which case the client stays where
+// it is rather than moving between equivalent nodes.
+func isPreferredMember(info *gossipApi.ClusterInfo, selected *gossipApi.MemberInfo, current EndPoint, nodePreference NodePreference) bool {
+	for _, member := range info.Members {
+		address := fmt.Sprintf("%s:%d", member.GetHttpEndPoint().GetAddress(), member.GetHttpEndPoint().GetPort())
+		if address != current.String() || !member.GetIsAlive() {
+			continue
+		}
+
+		if nodePreference == NodePreferenceRandom {
+			for _, allowedState := range allowedNodeState() {
+				if member.State == allowedState {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		return member.State == selected.State
+	}
+
+	return false
+}
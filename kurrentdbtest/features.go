@@ -0,0 +1,100 @@
+package kurrentdbtest
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/gossip"
+	"github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/serverfeatures"
+	"github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/shared"
+)
+
+type serverFeaturesServer struct {
+	serverfeatures.UnimplementedServerFeaturesServer
+}
+
+func (s *serverFeaturesServer) GetSupportedMethods(context.Context, *shared.Empty) (*serverfeatures.SupportedMethods, error) {
+	methods := []*serverfeatures.SupportedMethod{
+		{ServiceName: "event_store.client.streams.streams", MethodName: "read"},
+		{ServiceName: "event_store.client.streams.streams", MethodName: "append"},
+		{ServiceName: "event_store.client.streams.streams", MethodName: "delete"},
+		{ServiceName: "event_store.client.streams.streams", MethodName: "tombstone"},
+		{ServiceName: "event_store.client.gossip.gossip", MethodName: "read"},
+		{ServiceName: "event_store.client.server_features.serverfeatures", MethodName: "getsupportedmethods"},
+		{ServiceName: "kurrentdb.protocol.v2.streams.streamsservice", MethodName: "appendrecords"},
+	}
+
+	return &serverfeatures.SupportedMethods{
+		Methods:                 methods,
+		EventStoreServerVersion: Version,
+	}, nil
+}
+
+// Member is a cluster node reported by the gossip of the server.
+type Member struct {
+	// Address of the node, as host:port.
+	Addr  string
+	State kurrentdb.NodeState
+	// Dead nodes are reported as such, clients don't pick them.
+	Dead bool
+}
+
+// SetGossip replaces the members reported by the gossip of the server. Without members, the server reports itself as
+// the only node of the cluster, a leader.
+func (server *Server) SetGossip(members ...Member) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	server.members = members
+}
+
+// gossipServer describes the members set through SetGossip, by default a cluster made of a single leader, the server
+// itself.
+type gossipServer struct {
+	gossip.UnimplementedGossipServer
+	server *Server
+}
+
+func (s *gossipServer) Read(context.Context, *shared.Empty) (*gossip.ClusterInfo, error) {
+	s.server.mu.Lock()
+	members := s.server.members
+	s.server.mu.Unlock()
+
+	if len(members) == 0 {
+		members = []Member{{Addr: s.server.Addr(), State: kurrentdb.NodeStateLeader}}
+	}
+
+	info := &gossip.ClusterInfo{}
+	for _, member := range members {
+		host, portString, err := net.SplitHostPort(member.Addr)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid member address %q: %v", member.Addr, err)
+		}
+
+		port, err := strconv.ParseUint(portString, 10, 32)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid member port %q: %v", member.Addr, err)
+		}
+
+		info.Members = append(info.Members, &gossip.MemberInfo{
+			InstanceId: protoUUID(uuid.NewSHA1(uuid.NameSpaceURL, []byte(member.Addr))),
+			TimeStamp:  time.Now().UnixNano() / 100,
+			State:      gossip.MemberInfo_VNodeState(gossip.MemberInfo_VNodeState_value[string(member.State)]),
+			IsAlive:    !member.Dead,
+			HttpEndPoint: &gossip.EndPoint{
+				Address: host,
+				Port:    uint32(port),
+			},
+		})
+	}
+
+	return info, nil
+}
@@ -0,0 +1,209 @@
+// Package kurrentdbtest provides an in-memory KurrentDB server for unit tests.
+//
+// The server speaks the gRPC protocol of a single KurrentDB node, over TLS with NewTLSServer, without checking
+// credentials. It implements
+// reading, subscribing to, appending to, deleting and tombstoning streams, the v2 AppendRecords operation, server
+// features and gossip. Expected stream states are checked like a real node does and $all preserves the order in
+// which events were written. Persistent subscriptions, projections and BatchAppend are not implemented.
+//
+//	server, err := kurrentdbtest.NewServer()
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer server.Close()
+//
+//	client, err := server.NewClient()
+package kurrentdbtest
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+	"github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/gossip"
+	"github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/serverfeatures"
+	api "github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/streams"
+	apiV2 "github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v2/streams/streams"
+)
+
+// Version is the server version advertised by the fake server.
+const Version = "26.1.0"
+
+// Server is an in-memory KurrentDB node listening on a loopback address.
+type Server struct {
+	listener      *trackingListener
+	grpc          *grpc.Server
+	tls           bool
+	mu            sync.Mutex
+	log           []*record
+	streams       map[string]*stream
+	changed       chan struct{}
+	members       []Member
+	authorization string
+}
+
+// NewServer starts a server listening on a random port of the loopback interface.
+func NewServer() (*Server, error) {
+	return newServer(false)
+}
+
+// NewTLSServer starts a server listening on a random port of the loopback interface, securing connections with
+// config. Clients must trust the server certificate.
+func NewTLSServer(config *tls.Config) (*Server, error) {
+	return newServer(true, grpc.Creds(credentials.NewTLS(config)))
+}
+
+func newServer(secure bool, options ...grpc.ServerOption) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on a loopback address: %w", err)
+	}
+
+	server := &Server{
+		listener: &trackingListener{Listener: listener, conns: make(map[net.Conn]struct{})},
+		tls:      secure,
+		streams:  make(map[string]*stream),
+		changed:  make(chan struct{}),
+	}
+
+	server.grpc = grpc.NewServer(append(options,
+		// Clients ping idle connections every 10 seconds by default, which a stock gRPC server considers abusive.
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             time.Second,
+			PermitWithoutStream: true,
+		}),
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			server.recordAuthorization(ctx)
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			server.recordAuthorization(stream.Context())
+			return handler(srv, stream)
+		}),
+		// Interceptors don't run for the services the server doesn't implement, which still record their authorization.
+		grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+			server.recordAuthorization(stream.Context())
+
+			method, _ := grpc.MethodFromServerStream(stream)
+			return status.Errorf(codes.Unimplemented, "unknown method %s", method)
+		}),
+	)...)
+
+	api.RegisterStreamsServer(server.grpc, &streamsServer{server: server})
+	apiV2.RegisterStreamsServiceServer(server.grpc, &streamsV2Server{server: server})
+	serverfeatures.RegisterServerFeaturesServer(server.grpc, &serverFeaturesServer{})
+	gossip.RegisterGossipServer(server.grpc, &gossipServer{server: server})
+
+	go func() {
+		_ = server.grpc.Serve(server.listener)
+	}()
+
+	return server, nil
+}
+
+// Addr returns the host:port the server listens on.
+func (server *Server) Addr() string {
+	return server.listener.Addr().String()
+}
+
+// ConnectionString returns a connection string pointing at the server.
+func (server *Server) ConnectionString() string {
+	if server.tls {
+		return fmt.Sprintf("kurrentdb://%s", server.Addr())
+	}
+
+	return fmt.Sprintf("kurrentdb://%s?tls=false", server.Addr())
+}
+
+// NewClient creates a client connected to the server. The client must be closed by the caller.
+func (server *Server) NewClient() (*kurrentdb.Client, error) {
+	config, err := kurrentdb.ParseConnectionString(server.ConnectionString())
+	if err != nil {
+		return nil, err
+	}
+
+	return kurrentdb.NewClient(config)
+}
+
+// Close stops the server, dropping the pending calls and live subscriptions.
+func (server *Server) Close() {
+	server.grpc.Stop()
+}
+
+// DropConnections closes every client connection, like a network failure would, while the server keeps accepting
+// new ones.
+func (server *Server) DropConnections() {
+	server.listener.closeConns()
+}
+
+// Authorization returns the authorization header of the last call the server received, empty if it had none.
+func (server *Server) Authorization() string {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	return server.authorization
+}
+
+func (server *Server) recordAuthorization(ctx context.Context) {
+	var authorization string
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		authorization = values[0]
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	server.authorization = authorization
+}
+
+// trackingListener keeps the connections it accepted, so that they can be dropped.
+type trackingListener struct {
+	net.Listener
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+}
+
+func (listener *trackingListener) Accept() (net.Conn, error) {
+	conn, err := listener.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+
+	listener.conns[conn] = struct{}{}
+	return conn, nil
+}
+
+func (listener *trackingListener) closeConns() {
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+
+	for conn := range listener.conns {
+		_ = conn.Close()
+	}
+
+	listener.conns = make(map[net.Conn]struct{})
+}
+
+// Reset removes every stream and event from the server.
+func (server *Server) Reset() {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	server.log = nil
+	server.streams = make(map[string]*stream)
+	server.notifyLocked()
+}
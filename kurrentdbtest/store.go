@@ -0,0 +1,138 @@
+package kurrentdbtest
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Raw expected states, as used by the v2 protocol.
+const (
+	stateNoStream int64 = -1
+	stateAny      int64 = -2
+	stateExists   int64 = -4
+)
+
+// record is an event written to the log.
+type record struct {
+	id             uuid.UUID
+	stream         string
+	revision       uint64
+	position       uint64
+	eventType      string
+	contentType    string
+	created        time.Time
+	data           []byte
+	customMetadata []byte
+}
+
+// stream tracks the events of a single stream. Soft-deleted events stay in the log but are hidden from stream reads.
+type stream struct {
+	records        []*record
+	truncateBefore uint64
+	tombstoned     bool
+}
+
+// exists tells if the stream has visible events.
+func (s *stream) exists() bool {
+	return s != nil && !s.tombstoned && uint64(len(s.records)) > s.truncateBefore
+}
+
+// state returns the current revision of the stream, or stateNoStream.
+func (s *stream) state() int64 {
+	if !s.exists() {
+		return stateNoStream
+	}
+
+	return int64(len(s.records) - 1)
+}
+
+// nextRevision returns the revision of the next event appended to the stream.
+func (s *stream) nextRevision() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return uint64(len(s.records))
+}
+
+// visible returns the events of the stream which are not deleted.
+func (s *stream) visible() []*record {
+	if !s.exists() {
+		return nil
+	}
+
+	return s.records[s.truncateBefore:]
+}
+
+// check tells if the stream is at the expected state.
+func (s *stream) check(expected int64) bool {
+	switch expected {
+	case stateAny:
+		return true
+	case stateNoStream:
+		return !s.exists()
+	case stateExists:
+		return s.exists()
+	default:
+		return s.state() == expected
+	}
+}
+
+// proposed is an event about to be written.
+type proposed struct {
+	stream         string
+	id             uuid.UUID
+	eventType      string
+	contentType    string
+	data           []byte
+	customMetadata []byte
+}
+
+// appendLocked writes events at the end of the log. The caller must hold the server's lock and have checked the
+// expected state of every stream.
+func (server *Server) appendLocked(events []proposed) []*record {
+	created := time.Now().UTC()
+	written := make([]*record, 0, len(events))
+
+	for _, event := range events {
+		target := server.streams[event.stream]
+		if target == nil {
+			target = &stream{}
+			server.streams[event.stream] = target
+		}
+
+		rec := &record{
+			id:             event.id,
+			stream:         event.stream,
+			revision:       target.nextRevision(),
+			position:       uint64(len(server.log) + 1),
+			eventType:      event.eventType,
+			contentType:    event.contentType,
+			created:        created,
+			data:           event.data,
+			customMetadata: event.customMetadata,
+		}
+
+		target.records = append(target.records, rec)
+		server.log = append(server.log, rec)
+		written = append(written, rec)
+	}
+
+	if len(written) > 0 {
+		server.notifyLocked()
+	}
+
+	return written
+}
+
+// notifyLocked wakes up the live subscriptions.
+func (server *Server) notifyLocked() {
+	close(server.changed)
+	server.changed = make(chan struct{})
+}
+
+// headLocked returns the position of the last event of the log.
+func (server *Server) headLocked() uint64 {
+	return uint64(len(server.log))
+}
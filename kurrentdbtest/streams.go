@@ -0,0 +1,675 @@
+package kurrentdbtest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+	"github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/shared"
+	api "github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/streams"
+)
+
+type streamsServer struct {
+	api.UnimplementedStreamsServer
+	server *Server
+}
+
+func (s *streamsServer) Read(request *api.ReadReq, stream grpc.ServerStreamingServer[api.ReadResp]) error {
+	options := request.GetOptions()
+	if options == nil {
+		return status.Error(codes.InvalidArgument, "read options are required")
+	}
+
+	if options.GetSubscription() != nil {
+		if options.GetAll() != nil {
+			return s.subscribeToAll(options, stream)
+		}
+
+		return s.subscribeToStream(options, stream)
+	}
+
+	if options.GetAll() != nil {
+		return s.readAll(options, stream)
+	}
+
+	return s.readStream(options, stream)
+}
+
+func (s *streamsServer) readStream(options *api.ReadReq_Options, stream grpc.ServerStreamingServer[api.ReadResp]) error {
+	streamOptions := options.GetStream()
+	name := string(streamOptions.GetStreamIdentifier().GetStreamName())
+
+	s.server.mu.Lock()
+	target := s.server.streams[name]
+	if target != nil && target.tombstoned {
+		s.server.mu.Unlock()
+		return streamDeletedError(stream.SetTrailer, name)
+	}
+
+	events := target.visible()
+	s.server.mu.Unlock()
+
+	if len(events) == 0 {
+		return stream.Send(&api.ReadResp{
+			Content: &api.ReadResp_StreamNotFound_{
+				StreamNotFound: &api.ReadResp_StreamNotFound{
+					StreamIdentifier: streamOptions.GetStreamIdentifier(),
+				},
+			},
+		})
+	}
+
+	backwards := options.GetReadDirection() == api.ReadReq_Options_Backwards
+	selected := make([]*record, 0, len(events))
+
+	if backwards {
+		from := events[len(events)-1].revision
+		if streamOptions.GetStart() != nil {
+			from = 0
+		} else if streamOptions.GetEnd() == nil {
+			from = streamOptions.GetRevision()
+		}
+
+		for i := len(events) - 1; i >= 0; i-- {
+			if events[i].revision <= from {
+				selected = append(selected, events[i])
+			}
+		}
+	} else if streamOptions.GetEnd() == nil {
+		from := streamOptions.GetRevision()
+		for _, event := range events {
+			if event.revision >= from {
+				selected = append(selected, event)
+			}
+		}
+	}
+
+	return sendEvents(stream, options, selected)
+}
+
+func (s *streamsServer) readAll(options *api.ReadReq_Options, stream grpc.ServerStreamingServer[api.ReadResp]) error {
+	allOptions := options.GetAll()
+	filter, err := newFilter(options.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	s.server.mu.Lock()
+	log := s.server.log
+	s.server.mu.Unlock()
+
+	backwards := options.GetReadDirection() == api.ReadReq_Options_Backwards
+	selected := make([]*record, 0)
+
+	if backwards {
+		// Reading backwards from a position returns the events written before it.
+		before := uint64(len(log)) + 1
+		if allOptions.GetStart() != nil {
+			before = 0
+		} else if position := allOptions.GetPosition(); position != nil {
+			before = position.GetCommitPosition()
+		}
+
+		for i := len(log) - 1; i >= 0; i-- {
+			if log[i].position < before && filter.matches(log[i]) {
+				selected = append(selected, log[i])
+			}
+		}
+	} else {
+		from := uint64(0)
+		if allOptions.GetEnd() != nil {
+			from = uint64(len(log)) + 1
+		} else if position := allOptions.GetPosition(); position != nil {
+			from = position.GetCommitPosition()
+		}
+
+		for _, event := range log {
+			if event.position >= from && filter.matches(event) {
+				selected = append(selected, event)
+			}
+		}
+	}
+
+	return sendEvents(stream, options, selected)
+}
+
+func sendEvents(stream grpc.ServerStreamingServer[api.ReadResp], options *api.ReadReq_Options, events []*record) error {
+	if count := options.GetCount(); uint64(len(events)) > count {
+		events = events[:count]
+	}
+
+	for _, event := range events {
+		if err := stream.Send(readEvent(event, options)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *streamsServer) subscribeToStream(options *api.ReadReq_Options, stream grpc.ServerStreamingServer[api.ReadResp]) error {
+	streamOptions := options.GetStream()
+	name := string(streamOptions.GetStreamIdentifier().GetStreamName())
+
+	s.server.mu.Lock()
+	target := s.server.streams[name]
+	next := uint64(0)
+	if streamOptions.GetEnd() != nil {
+		next = target.nextRevision()
+	} else if streamOptions.GetStart() == nil {
+		next = streamOptions.GetRevision() + 1
+	}
+	s.server.mu.Unlock()
+
+	if err := sendConfirmation(stream); err != nil {
+		return err
+	}
+
+	caughtUp := false
+	for {
+		s.server.mu.Lock()
+		target := s.server.streams[name]
+		changed := s.server.changed
+		if target != nil && target.tombstoned {
+			s.server.mu.Unlock()
+			return streamDeletedError(stream.SetTrailer, name)
+		}
+
+		var events []*record
+		if target != nil && next < target.nextRevision() {
+			events = target.records[max(next, target.truncateBefore):]
+			next = target.nextRevision()
+		}
+		state := target.state()
+		s.server.mu.Unlock()
+
+		for _, event := range events {
+			if err := stream.Send(readEvent(event, options)); err != nil {
+				return err
+			}
+		}
+
+		if len(events) > 0 {
+			continue
+		}
+
+		if !caughtUp {
+			caughtUp = true
+			message := &api.ReadResp_CaughtUp{Timestamp: timestamppb.Now()}
+			if state != stateNoStream {
+				message.StreamRevision = &state
+			}
+
+			if err := stream.Send(&api.ReadResp{Content: &api.ReadResp_CaughtUp_{CaughtUp: message}}); err != nil {
+				return err
+			}
+		}
+
+		if err := wait(stream.Context(), changed); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *streamsServer) subscribeToAll(options *api.ReadReq_Options, stream grpc.ServerStreamingServer[api.ReadResp]) error {
+	allOptions := options.GetAll()
+	filter, err := newFilter(options.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	// next is the index in the log of the next event to deliver, which is also the position of the last delivered one.
+	s.server.mu.Lock()
+	next := 0
+	if allOptions.GetEnd() != nil {
+		next = len(s.server.log)
+	} else if position := allOptions.GetPosition(); position != nil {
+		next = int(min(position.GetCommitPosition(), uint64(len(s.server.log))))
+	}
+	s.server.mu.Unlock()
+
+	if err := sendConfirmation(stream); err != nil {
+		return err
+	}
+
+	caughtUp := false
+	scanned := uint32(0)
+	for {
+		s.server.mu.Lock()
+		log := s.server.log
+		changed := s.server.changed
+		s.server.mu.Unlock()
+
+		var events []*record
+		if next < len(log) {
+			events = log[next:]
+			next = len(log)
+		}
+
+		for _, event := range events {
+			if filter.matches(event) {
+				if err := stream.Send(readEvent(event, options)); err != nil {
+					return err
+				}
+			}
+
+			scanned += 1
+			if filter.checkpointInterval > 0 && scanned >= filter.checkpointInterval {
+				scanned = 0
+				if err := stream.Send(checkpoint(event.position)); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(events) > 0 {
+			continue
+		}
+
+		if !caughtUp {
+			caughtUp = true
+			message := &api.ReadResp_CaughtUp{Timestamp: timestamppb.Now()}
+			if len(log) > 0 {
+				head := uint64(len(log))
+				message.Position = &api.ReadResp_Position{CommitPosition: head, PreparePosition: head}
+			}
+
+			if err := stream.Send(&api.ReadResp{Content: &api.ReadResp_CaughtUp_{CaughtUp: message}}); err != nil {
+				return err
+			}
+		}
+
+		if err := wait(stream.Context(), changed); err != nil {
+			return err
+		}
+	}
+}
+
+func sendConfirmation(stream grpc.ServerStreamingServer[api.ReadResp]) error {
+	return stream.Send(&api.ReadResp{
+		Content: &api.ReadResp_Confirmation{
+			Confirmation: &api.ReadResp_SubscriptionConfirmation{SubscriptionId: uuid.NewString()},
+		},
+	})
+}
+
+func checkpoint(position uint64) *api.ReadResp {
+	return &api.ReadResp{
+		Content: &api.ReadResp_Checkpoint_{
+			Checkpoint: &api.ReadResp_Checkpoint{
+				CommitPosition:  position,
+				PreparePosition: position,
+				Timestamp:       timestamppb.Now(),
+			},
+		},
+	}
+}
+
+// wait blocks until the log changed or the subscription is cancelled.
+func wait(ctx context.Context, changed <-chan struct{}) error {
+	select {
+	case <-changed:
+		return nil
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func (s *streamsServer) Append(stream grpc.ClientStreamingServer[api.AppendReq, api.AppendResp]) error {
+	request, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	options := request.GetOptions()
+	if options == nil {
+		return status.Error(codes.InvalidArgument, "the first append message must carry the append options")
+	}
+
+	name := string(options.GetStreamIdentifier().GetStreamName())
+	expected := expectedState(options)
+	events := make([]proposed, 0)
+
+	for {
+		request, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		message := request.GetProposedMessage()
+		if message == nil {
+			return status.Error(codes.InvalidArgument, "append options can only be sent once")
+		}
+
+		id, err := uuidFromProto(message.GetId())
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid event id: %v", err)
+		}
+
+		events = append(events, proposed{
+			stream:         name,
+			id:             id,
+			eventType:      message.GetMetadata()["type"],
+			contentType:    message.GetMetadata()["content-type"],
+			data:           message.GetData(),
+			customMetadata: message.GetCustomMetadata(),
+		})
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	target := s.server.streams[name]
+	if target != nil && target.tombstoned {
+		return streamDeletedError(stream.SetTrailer, name)
+	}
+
+	written, ok := target.replayed(expected, events)
+	if !ok && !target.check(expected) {
+		return stream.SendAndClose(&api.AppendResp{
+			Result: &api.AppendResp_WrongExpectedVersion_{
+				WrongExpectedVersion: wrongExpectedVersion(expected, target.state()),
+			},
+		})
+	}
+
+	if !ok {
+		written = s.server.appendLocked(events)
+	}
+
+	success := &api.AppendResp_Success{}
+	if len(written) == 0 {
+		// Nothing was written, the stream stays as it was.
+		if state := target.state(); state == stateNoStream {
+			success.CurrentRevisionOption = &api.AppendResp_Success_NoStream{NoStream: &shared.Empty{}}
+		} else {
+			success.CurrentRevisionOption = &api.AppendResp_Success_CurrentRevision{CurrentRevision: uint64(state)}
+		}
+
+		success.PositionOption = &api.AppendResp_Success_NoPosition{NoPosition: &shared.Empty{}}
+	} else {
+		last := written[len(written)-1]
+		success.CurrentRevisionOption = &api.AppendResp_Success_CurrentRevision{CurrentRevision: last.revision}
+		success.PositionOption = &api.AppendResp_Success_Position{
+			Position: &api.AppendResp_Position{CommitPosition: last.position, PreparePosition: last.position},
+		}
+	}
+
+	return stream.SendAndClose(&api.AppendResp{Result: &api.AppendResp_Success_{Success: success}})
+}
+
+// replayed tells if the events were already written by a previous attempt of the same append, in which case the
+// append succeeds without writing them again.
+func (s *stream) replayed(expected int64, events []proposed) ([]*record, bool) {
+	if s == nil || len(events) == 0 || expected == stateAny || expected == stateExists {
+		return nil, false
+	}
+
+	from := uint64(expected + 1)
+	if from+uint64(len(events)) > s.nextRevision() {
+		return nil, false
+	}
+
+	written := s.records[from : from+uint64(len(events))]
+	for i, event := range events {
+		if written[i].id != event.id {
+			return nil, false
+		}
+	}
+
+	return written, true
+}
+
+func (s *streamsServer) Delete(ctx context.Context, request *api.DeleteReq) (*api.DeleteResp, error) {
+	options := request.GetOptions()
+	name := string(options.GetStreamIdentifier().GetStreamName())
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	target := s.server.streams[name]
+	if target != nil && target.tombstoned {
+		return nil, streamDeletedError(trailerSetter(ctx), name)
+	}
+
+	expected := expectedState(options)
+	if !target.check(expected) {
+		return nil, wrongExpectedVersionError(ctx, expected, target.state())
+	}
+
+	if target != nil {
+		target.truncateBefore = target.nextRevision()
+	}
+
+	head := s.server.headLocked()
+
+	return &api.DeleteResp{
+		PositionOption: &api.DeleteResp_Position_{
+			Position: &api.DeleteResp_Position{CommitPosition: head, PreparePosition: head},
+		},
+	}, nil
+}
+
+func (s *streamsServer) Tombstone(ctx context.Context, request *api.TombstoneReq) (*api.TombstoneResp, error) {
+	options := request.GetOptions()
+	name := string(options.GetStreamIdentifier().GetStreamName())
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	target := s.server.streams[name]
+	if target != nil && target.tombstoned {
+		return nil, streamDeletedError(trailerSetter(ctx), name)
+	}
+
+	expected := expectedState(options)
+	if !target.check(expected) {
+		return nil, wrongExpectedVersionError(ctx, expected, target.state())
+	}
+
+	if target == nil {
+		target = &stream{}
+		s.server.streams[name] = target
+	}
+
+	target.tombstoned = true
+	s.server.notifyLocked()
+	head := s.server.headLocked()
+
+	return &api.TombstoneResp{
+		PositionOption: &api.TombstoneResp_Position_{
+			Position: &api.TombstoneResp_Position{CommitPosition: head, PreparePosition: head},
+		},
+	}, nil
+}
+
+// expectedStateOptions is implemented by the options of appends, deletes and tombstones.
+type expectedStateOptions interface {
+	GetRevision() uint64
+	GetNoStream() *shared.Empty
+	GetAny() *shared.Empty
+	GetStreamExists() *shared.Empty
+}
+
+func expectedState(options expectedStateOptions) int64 {
+	switch {
+	case options.GetAny() != nil:
+		return stateAny
+	case options.GetNoStream() != nil:
+		return stateNoStream
+	case options.GetStreamExists() != nil:
+		return stateExists
+	default:
+		return int64(options.GetRevision())
+	}
+}
+
+func wrongExpectedVersion(expected int64, current int64) *api.AppendResp_WrongExpectedVersion {
+	message := &api.AppendResp_WrongExpectedVersion{}
+
+	if current == stateNoStream {
+		message.CurrentRevisionOption = &api.AppendResp_WrongExpectedVersion_CurrentNoStream{CurrentNoStream: &shared.Empty{}}
+	} else {
+		message.CurrentRevisionOption = &api.AppendResp_WrongExpectedVersion_CurrentRevision{CurrentRevision: uint64(current)}
+	}
+
+	switch expected {
+	case stateAny:
+		message.ExpectedRevisionOption = &api.AppendResp_WrongExpectedVersion_ExpectedAny{ExpectedAny: &shared.Empty{}}
+	case stateNoStream:
+		message.ExpectedRevisionOption = &api.AppendResp_WrongExpectedVersion_ExpectedNoStream{ExpectedNoStream: &shared.Empty{}}
+	case stateExists:
+		message.ExpectedRevisionOption = &api.AppendResp_WrongExpectedVersion_ExpectedStreamExists{ExpectedStreamExists: &shared.Empty{}}
+	default:
+		message.ExpectedRevisionOption = &api.AppendResp_WrongExpectedVersion_ExpectedRevision{ExpectedRevision: uint64(expected)}
+	}
+
+	return message
+}
+
+// trailerSetter returns a function setting the trailers of a unary call.
+func trailerSetter(ctx context.Context) func(metadata.MD) {
+	return func(md metadata.MD) {
+		_ = grpc.SetTrailer(ctx, md)
+	}
+}
+
+// streamDeletedError reports a tombstoned stream the way a KurrentDB node does, through the call's trailers.
+func streamDeletedError(setTrailer func(metadata.MD), name string) error {
+	setTrailer(metadata.Pairs("exception", "stream-deleted", "stream-name", name))
+	return status.Errorf(codes.FailedPrecondition, "event stream '%s' is deleted", name)
+}
+
+func wrongExpectedVersionError(ctx context.Context, expected int64, current int64) error {
+	trailerSetter(ctx)(metadata.Pairs(
+		"exception", "wrong-expected-version",
+		"expected-version", strconv.FormatInt(expected, 10),
+		"actual-version", strconv.FormatInt(current, 10),
+	))
+
+	return status.Errorf(codes.FailedPrecondition, "wrong expected version: expected %d but got %d", expected, current)
+}
+
+func readEvent(event *record, options *api.ReadReq_Options) *api.ReadResp {
+	id := protoUUID(event.id)
+	if options.GetUuidOption().GetString_() != nil {
+		id = &shared.UUID{Value: &shared.UUID_String_{String_: event.id.String()}}
+	}
+
+	return &api.ReadResp{
+		Content: &api.ReadResp_Event{
+			Event: &api.ReadResp_ReadEvent{
+				Event: &api.ReadResp_ReadEvent_RecordedEvent{
+					Id:               id,
+					StreamIdentifier: &shared.StreamIdentifier{StreamName: []byte(event.stream)},
+					StreamRevision:   event.revision,
+					PreparePosition:  event.position,
+					CommitPosition:   event.position,
+					Metadata: map[string]string{
+						"type":         event.eventType,
+						"content-type": event.contentType,
+						// .NET ticks since the unix epoch.
+						"created": strconv.FormatInt(event.created.UnixNano()/100, 10),
+					},
+					CustomMetadata: event.customMetadata,
+					Data:           event.data,
+				},
+				Position: &api.ReadResp_ReadEvent_CommitPosition{CommitPosition: event.position},
+			},
+		},
+	}
+}
+
+func protoUUID(id uuid.UUID) *shared.UUID {
+	most, least := kurrentdb.UUIDAsInt64(id)
+	return &shared.UUID{
+		Value: &shared.UUID_Structured_{
+			Structured: &shared.UUID_Structured{
+				MostSignificantBits:  most,
+				LeastSignificantBits: least,
+			},
+		},
+	}
+}
+
+func uuidFromProto(id *shared.UUID) (uuid.UUID, error) {
+	if structured := id.GetStructured(); structured != nil {
+		return kurrentdb.ParseUUIDFromInt64(structured.MostSignificantBits, structured.LeastSignificantBits)
+	}
+
+	return uuid.Parse(id.GetString_())
+}
+
+// filter selects the events of $all reads and subscriptions.
+type filter struct {
+	byEventType        bool
+	regex              *regexp.Regexp
+	prefixes           []string
+	checkpointInterval uint32
+}
+
+func newFilter(options *api.ReadReq_Options_FilterOptions) (*filter, error) {
+	if options == nil {
+		return &filter{}, nil
+	}
+
+	expression := options.GetStreamIdentifier()
+	result := &filter{
+		checkpointInterval: options.GetMax() * options.GetCheckpointIntervalMultiplier(),
+	}
+
+	if options.GetEventType() != nil {
+		expression = options.GetEventType()
+		result.byEventType = true
+	}
+
+	result.prefixes = expression.GetPrefix()
+	if pattern := expression.GetRegex(); pattern != "" {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid filter regex: %v", err)
+		}
+
+		result.regex = regex
+	}
+
+	return result, nil
+}
+
+func (f *filter) matches(event *record) bool {
+	value := event.stream
+	if f.byEventType {
+		value = event.eventType
+	}
+
+	if f.regex != nil && !f.regex.MatchString(value) {
+		return false
+	}
+
+	if len(f.prefixes) == 0 {
+		return true
+	}
+
+	for _, prefix := range f.prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
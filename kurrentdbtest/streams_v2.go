@@ -0,0 +1,150 @@
+package kurrentdbtest
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	streamErrors "github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v2/streams/errors"
+	apiV2 "github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v2/streams/streams"
+)
+
+type streamsV2Server struct {
+	apiV2.UnimplementedStreamsServiceServer
+	server *Server
+}
+
+func (s *streamsV2Server) AppendRecords(_ context.Context, request *apiV2.AppendRecordsRequest) (*apiV2.AppendRecordsResponse, error) {
+	if len(request.GetRecords()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one record is required")
+	}
+
+	events := make([]proposed, 0, len(request.GetRecords()))
+	for _, record := range request.GetRecords() {
+		event, err := proposedFromRecord(record)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	for _, event := range events {
+		if target := s.server.streams[event.stream]; target != nil && target.tombstoned {
+			return nil, detailedError(codes.FailedPrecondition, "stream is tombstoned", &streamErrors.StreamTombstonedErrorDetails{
+				Stream: event.stream,
+			})
+		}
+	}
+
+	violations := make([]*streamErrors.ConsistencyViolation, 0)
+	for i, check := range request.GetChecks() {
+		stateCheck := check.GetStreamState()
+		if stateCheck == nil {
+			continue
+		}
+
+		target := s.server.streams[stateCheck.GetStream()]
+		if !target.check(stateCheck.GetExpectedState()) {
+			violations = append(violations, &streamErrors.ConsistencyViolation{
+				CheckIndex: int32(i),
+				Type: &streamErrors.ConsistencyViolation_StreamState{
+					StreamState: &streamErrors.ConsistencyViolation_StreamStateViolation{
+						Stream:        stateCheck.GetStream(),
+						ExpectedState: stateCheck.GetExpectedState(),
+						ActualState:   target.state(),
+					},
+				},
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return nil, detailedError(codes.FailedPrecondition, "consistency checks failed", &streamErrors.AppendConsistencyViolationErrorDetails{
+			Violations: violations,
+		})
+	}
+
+	written := s.server.appendLocked(events)
+
+	// One revision per stream, in the order the streams first appear in the request.
+	revisions := make([]*apiV2.StreamRevision, 0)
+	indexes := make(map[string]int)
+	for _, event := range written {
+		index, ok := indexes[event.stream]
+		if !ok {
+			index = len(revisions)
+			indexes[event.stream] = index
+			revisions = append(revisions, &apiV2.StreamRevision{Stream: event.stream})
+		}
+
+		revisions[index].Revision = int64(event.revision)
+	}
+
+	return &apiV2.AppendRecordsResponse{
+		Revisions: revisions,
+		Position:  int64(written[len(written)-1].position),
+	}, nil
+}
+
+func proposedFromRecord(record *apiV2.AppendRecord) (proposed, error) {
+	if record.GetStream() == "" {
+		return proposed{}, status.Error(codes.InvalidArgument, "every record must target a stream")
+	}
+
+	id := uuid.New()
+	if record.RecordId != nil {
+		parsed, err := uuid.Parse(record.GetRecordId())
+		if err != nil {
+			return proposed{}, status.Errorf(codes.InvalidArgument, "invalid record id: %v", err)
+		}
+
+		id = parsed
+	}
+
+	contentType := "application/octet-stream"
+	if record.GetSchema().GetFormat() == apiV2.SchemaFormat_SCHEMA_FORMAT_JSON {
+		contentType = "application/json"
+	}
+
+	var customMetadata []byte
+	if len(record.GetProperties()) > 0 {
+		properties := make(map[string]any, len(record.GetProperties()))
+		for key, value := range record.GetProperties() {
+			properties[key] = value.AsInterface()
+		}
+
+		data, err := json.Marshal(properties)
+		if err != nil {
+			return proposed{}, status.Errorf(codes.InvalidArgument, "invalid record properties: %v", err)
+		}
+
+		customMetadata = data
+	}
+
+	return proposed{
+		stream:         record.GetStream(),
+		id:             id,
+		eventType:      record.GetSchema().GetName(),
+		contentType:    contentType,
+		data:           record.GetData(),
+		customMetadata: customMetadata,
+	}, nil
+}
+
+// detailedError returns a status carrying the error details of the v2 protocol.
+func detailedError(code codes.Code, message string, detail protoadapt.MessageV1) error {
+	detailed, err := status.New(code, message).WithDetails(detail)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return detailed.Err()
+}
@@ -249,6 +249,44 @@ func ReadFromAllBackwards(db *kurrentdb.Client) {
 	// endregion read-from-all-stream-backwards-iterate
 }
 
+func ReadFromAllStreamFiltered(db *kurrentdb.Client) {
+	// region read-from-all-stream-filtered
+	options := kurrentdb.ReadAllOptions{
+		From: kurrentdb.Start{},
+		Filter: &kurrentdb.SubscriptionFilter{
+			Type:     kurrentdb.StreamFilterType,
+			Prefixes: []string{"order-"},
+		},
+	}
+	stream, err := db.ReadAll(context.Background(), options, 100)
+
+	if err != nil {
+		panic(err)
+	}
+
+	defer stream.Close()
+
+	for {
+		msg, err := stream.RecvMessage()
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			panic(err)
+		}
+
+		if msg.CheckPointReached != nil {
+			fmt.Printf("Checkpoint> %v", *msg.CheckPointReached)
+			continue
+		}
+
+		fmt.Printf("Event> %v", msg.EventAppeared)
+	}
+	// endregion read-from-all-stream-filtered
+}
+
 func ReadFromStreamResolvingLinkToS(db *kurrentdb.Client) {
 	// region read-from-all-stream-resolving-link-Tos
 	ropts := kurrentdb.ReadAllOptions{
@@ -0,0 +1,220 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdbtest"
+)
+
+type order struct {
+	Placed  bool
+	Total   float64
+	Shipped bool
+}
+
+type orderShipped struct {
+	OrderId string `json:"orderId"`
+}
+
+type orderCancelled struct {
+	Reason string `json:"reason"`
+}
+
+func TestAggregateRepository(t *testing.T) {
+	server, err := kurrentdbtest.NewServer()
+	require.NoError(t, err)
+	defer server.Close()
+
+	config, err := kurrentdb.ParseConnectionString(server.ConnectionString())
+	require.NoError(t, err)
+	config.Logger = kurrentdb.NoopLogging()
+	config.Serializer = kurrentdb.NewSerializer()
+	require.NoError(t, kurrentdb.RegisterEvent[orderPlaced](config.Serializer, "OrderPlaced", kurrentdb.JsonCodec{}))
+	require.NoError(t, kurrentdb.RegisterEvent[orderShipped](config.Serializer, "OrderShipped", kurrentdb.JsonCodec{}))
+	require.NoError(t, kurrentdb.RegisterEvent[orderCancelled](config.Serializer, "OrderCancelled", kurrentdb.JsonCodec{}))
+
+	client, err := kurrentdb.NewClient(config)
+	require.NoError(t, err)
+	defer client.Close()
+
+	orders := kurrentdb.NewAggregateRepository[order](client, "order-", nil)
+	require.NoError(t, kurrentdb.RegisterApply(orders, func(state *order, event orderPlaced) {
+		state.Placed = true
+		state.Total = event.Total
+	}))
+	require.NoError(t, kurrentdb.RegisterApply(orders, func(state *order, event orderShipped) {
+		state.Shipped = true
+	}))
+	assert.Error(t, kurrentdb.RegisterApply(orders, func(state *order, event orderShipped) {}))
+
+	t.Run("saveAndLoad", func(t *testing.T) {
+		aggregate := orders.New("1")
+		require.NoError(t, aggregate.Record(orderPlaced{OrderId: "1", Total: 42}))
+		assert.True(t, aggregate.State().Placed)
+		assert.Len(t, aggregate.Pending(), 1)
+
+		require.NoError(t, orders.Save(context.Background(), aggregate))
+		assert.Empty(t, aggregate.Pending())
+		assert.Equal(t, kurrentdb.Revision(0), aggregate.Revision())
+
+		require.NoError(t, aggregate.Record(orderShipped{OrderId: "1"}))
+		require.NoError(t, orders.Save(context.Background(), aggregate))
+		assert.Equal(t, kurrentdb.Revision(1), aggregate.Revision())
+
+		loaded, err := orders.Load(context.Background(), "1")
+		require.NoError(t, err)
+		assert.Equal(t, "1", loaded.ID())
+		assert.Equal(t, order{Placed: true, Total: 42, Shipped: true}, *loaded.State())
+		assert.Equal(t, kurrentdb.Revision(1), loaded.Revision())
+
+		// Saving without recorded events doesn't append anything.
+		require.NoError(t, orders.Save(context.Background(), loaded))
+		assert.Equal(t, kurrentdb.Revision(1), loaded.Revision())
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		first := orders.New("2")
+		require.NoError(t, first.Record(orderPlaced{OrderId: "2", Total: 10}))
+		require.NoError(t, orders.Save(context.Background(), first))
+
+		second, err := orders.Load(context.Background(), "2")
+		require.NoError(t, err)
+
+		require.NoError(t, first.Record(orderShipped{OrderId: "2"}))
+		require.NoError(t, orders.Save(context.Background(), first))
+
+		require.NoError(t, second.Record(orderShipped{OrderId: "2"}))
+		err = orders.Save(context.Background(), second)
+		requireErrorCode(t, err, kurrentdb.ErrorCodeWrongExpectedVersion)
+
+		var conflict *kurrentdb.AggregateConflictError
+		require.True(t, errors.As(err, &conflict))
+		assert.Equal(t, "2", conflict.ID)
+		assert.Equal(t, "order-2", conflict.Stream)
+		assert.Equal(t, kurrentdb.Revision(0), conflict.ExpectedRevision)
+		assert.Len(t, second.Pending(), 1)
+
+		// A new aggregate conflicts with an existing stream too.
+		duplicate := orders.New("2")
+		require.NoError(t, duplicate.Record(orderPlaced{OrderId: "2"}))
+		requireErrorCode(t, orders.Save(context.Background(), duplicate), kurrentdb.ErrorCodeWrongExpectedVersion)
+	})
+
+	t.Run("missingStream", func(t *testing.T) {
+		_, err := orders.Load(context.Background(), "missing")
+		requireErrorCode(t, err, kurrentdb.ErrorCodeResourceNotFound)
+	})
+
+	t.Run("unregisteredEvents", func(t *testing.T) {
+		aggregate := orders.New("3")
+		require.Error(t, aggregate.Record(orderPlaced{OrderId: "3"}, orderCancelled{Reason: "changed my mind"}))
+		assert.False(t, aggregate.State().Placed)
+		assert.Empty(t, aggregate.Pending())
+
+		_, err := client.AppendEvents(context.Background(), orders.StreamID("3"), kurrentdb.NoStream{}, orderPlaced{OrderId: "3"}, orderCancelled{Reason: "changed my mind"})
+		require.NoError(t, err)
+
+		_, err = orders.Load(context.Background(), "3")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no apply function is registered")
+	})
+
+	t.Run("snapshots", func(t *testing.T) {
+		applied := 0
+		snapshotted := kurrentdb.NewAggregateRepository[order](client, "snapshotted-order-", nil)
+		snapshotted.EnableSnapshots(kurrentdb.SnapshotOptions{Every: 4, MaxCount: 3})
+		require.NoError(t, kurrentdb.RegisterApply(snapshotted, func(state *order, event orderPlaced) {
+			applied++
+			state.Placed = true
+			state.Total += event.Total
+		}))
+
+		aggregate := snapshotted.New("1")
+		for i := 0; i < 10; i++ {
+			require.NoError(t, aggregate.Record(orderPlaced{OrderId: "1", Total: 1}))
+			require.NoError(t, snapshotted.Save(context.Background(), aggregate))
+		}
+
+		// Snapshots are kept out of the aggregates' category.
+		assert.Equal(t, "snapshot-snapshotted-order-1", snapshotted.SnapshotStreamID("1"))
+
+		// Snapshots were taken at 4 and 8 events.
+		stream, err := client.ReadStream(context.Background(), snapshotted.SnapshotStreamID("1"), kurrentdb.ReadStreamOptions{}, 10)
+		require.NoError(t, err)
+		defer stream.Close()
+
+		var snapshots int
+		for {
+			_, err := stream.Recv()
+			if err != nil {
+				break
+			}
+
+			snapshots++
+		}
+		assert.Equal(t, 2, snapshots)
+
+		metadata, err := client.GetStreamMetadata(context.Background(), snapshotted.SnapshotStreamID("1"), kurrentdb.ReadStreamOptions{
+			Direction: kurrentdb.Backwards,
+			From:      kurrentdb.End{},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, metadata.MaxCount())
+		assert.Equal(t, uint64(3), *metadata.MaxCount())
+
+		// Only the events after the latest snapshot, at revision 7, are applied.
+		applied = 0
+		loaded, err := snapshotted.Load(context.Background(), "1")
+		require.NoError(t, err)
+		assert.Equal(t, 2, applied)
+		assert.Equal(t, order{Placed: true, Total: 10}, *loaded.State())
+		assert.Equal(t, kurrentdb.Revision(9), loaded.Revision())
+
+		// A snapshot at the end of the stream leaves nothing to apply.
+		require.NoError(t, snapshotted.Snapshot(context.Background(), loaded))
+		applied = 0
+		loaded, err = snapshotted.Load(context.Background(), "1")
+		require.NoError(t, err)
+		assert.Equal(t, 0, applied)
+		assert.Equal(t, order{Placed: true, Total: 10}, *loaded.State())
+		assert.Equal(t, kurrentdb.Revision(9), loaded.Revision())
+
+		// A changed MaxCount is applied on the next snapshot.
+		resized := kurrentdb.NewAggregateRepository[order](client, "snapshotted-order-", nil)
+		resized.EnableSnapshots(kurrentdb.SnapshotOptions{MaxCount: 5})
+		require.NoError(t, resized.Snapshot(context.Background(), loaded))
+
+		metadata, err = client.GetStreamMetadata(context.Background(), snapshotted.SnapshotStreamID("1"), kurrentdb.ReadStreamOptions{
+			Direction: kurrentdb.Backwards,
+			From:      kurrentdb.End{},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, metadata.MaxCount())
+		assert.Equal(t, uint64(5), *metadata.MaxCount())
+
+		// Unsaved events can't be snapshotted.
+		require.NoError(t, loaded.Record(orderPlaced{OrderId: "1", Total: 1}))
+		require.Error(t, snapshotted.Snapshot(context.Background(), loaded))
+
+		// A snapshot that can't be decoded is ignored.
+		_, err = client.AppendToStream(context.Background(), snapshotted.SnapshotStreamID("1"), kurrentdb.AppendToStreamOptions{}, kurrentdb.EventData{
+			EventType:   "Snapshot",
+			ContentType: kurrentdb.ContentTypeJson,
+			Data:        []byte("not json"),
+			Metadata:    []byte(`{"revision": 9}`),
+		})
+		require.NoError(t, err)
+
+		applied = 0
+		loaded, err = snapshotted.Load(context.Background(), "1")
+		require.NoError(t, err)
+		assert.Equal(t, 10, applied)
+		assert.Equal(t, order{Placed: true, Total: 10}, *loaded.State())
+	})
+}
@@ -99,3 +99,48 @@ func (s *AppendTestSuite) TestMetadataOperation() {
 	assert.NoError(s.T(), err, "Error reading stream metadata")
 	assert.Equal(s.T(), meta, *metaActual, "Metadata should match")
 }
+
+func (s *AppendTestSuite) TestBatchAppenderPipelinesAppends() {
+	client := s.fixture.Client()
+
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	appender, err := client.NewBatchAppender(ctx, kurrentdb.BatchAppenderOptions{MaxBatchSize: 2})
+	if err != nil {
+		if esErr, ok := kurrentdb.FromError(err); !ok && esErr.Code() == kurrentdb.ErrorCodeUnsupportedFeature {
+			s.T().Skip("batch append is not supported by the server")
+		}
+	}
+	s.Require().NoError(err)
+	defer appender.Close()
+
+	streamIds := make([]string, 10)
+	futures := make([]*kurrentdb.AppendFuture, 10)
+
+	// Act
+	for i := range futures {
+		events := []kurrentdb.EventData{s.fixture.CreateTestEvent(), s.fixture.CreateTestEvent(), s.fixture.CreateTestEvent()}
+		streamIds[i] = s.fixture.NewStreamId()
+		futures[i] = appender.Append(ctx, streamIds[i], kurrentdb.BatchAppendOptions{
+			StreamState: kurrentdb.NoStream{},
+		}, events...)
+	}
+
+	conflict := appender.Append(ctx, streamIds[0], kurrentdb.BatchAppendOptions{
+		StreamState: kurrentdb.Revision(42),
+	}, s.fixture.CreateTestEvent())
+
+	// Assert
+	for i, future := range futures {
+		result, err := future.Result()
+		s.Require().NoError(err, "append to %s failed", streamIds[i])
+		s.Equal(uint64(2), result.NextExpectedVersion)
+	}
+
+	_, err = conflict.Result()
+	esErr, ok := kurrentdb.FromError(err)
+	s.False(ok)
+	s.Equal(kurrentdb.ErrorCodeWrongExpectedVersion, esErr.Code())
+}
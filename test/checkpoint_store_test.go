@@ -0,0 +1,39 @@
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+)
+
+func TestFileCheckpointStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	store := kurrentdb.NewFileCheckpointStore(path)
+
+	checkpoint, err := store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	revision := uint64(42)
+	err = store.Store(context.Background(), kurrentdb.Checkpoint{
+		Position: &kurrentdb.Position{Commit: 123, Prepare: 456},
+		Revision: &revision,
+	})
+	require.NoError(t, err)
+
+	checkpoint, err = kurrentdb.NewFileCheckpointStore(path).Load(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, checkpoint)
+	assert.Equal(t, kurrentdb.Position{Commit: 123, Prepare: 456}, *checkpoint.Position)
+	assert.Equal(t, revision, *checkpoint.Revision)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files should be cleaned up")
+}
@@ -0,0 +1,99 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdbtest"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	server, err := kurrentdbtest.NewServer()
+	require.NoError(t, err)
+	defer server.Close()
+
+	host, port, err := net.SplitHostPort(server.Addr())
+	require.NoError(t, err)
+
+	dns := newStubDNS(t)
+
+	config, err := kurrentdb.ParseConnectionString(fmt.Sprintf(
+		"kurrentdb+discover://breaker.cluster.test:%s?tls=false&maxDiscoverAttempts=1&circuitBreakerCooldown=300", port))
+	require.NoError(t, err)
+	config.Logger = kurrentdb.NoopLogging()
+	config.Resolver = dns.resolver()
+
+	client, err := kurrentdb.NewClient(config)
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx := context.Background()
+	events := client.WatchConnection(ctx)
+
+	err = client.Connect(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "maximum discovery attempt count reached")
+	require.NotNil(t, nextConnectionEvent(t, events).Discovering)
+	require.NotNil(t, nextConnectionEvent(t, events).Disconnected)
+
+	t.Run("failsFastWhileOpen", func(t *testing.T) {
+		start := time.Now()
+		_, err := client.ReadStream(ctx, "breaker", kurrentdb.ReadStreamOptions{}, 1)
+		requireErrorCode(t, err, kurrentdb.ErrorCodeCircuitOpen)
+		assert.Less(t, time.Since(start), 100*time.Millisecond)
+
+		select {
+		case event := <-events:
+			assert.Nil(t, event.Discovering, "no discovery should run while the circuit is open")
+		default:
+		}
+	})
+
+	t.Run("halfOpensAndRecovers", func(t *testing.T) {
+		dns.setA("breaker.cluster.test", net.ParseIP(host))
+
+		require.Eventually(t, func() bool {
+			return client.Connect(ctx) == nil
+		}, 5*time.Second, 50*time.Millisecond)
+
+		connected := nextConnectedEvent(t, events)
+		assert.Equal(t, net.JoinHostPort(host, port), connected.Endpoint.String())
+
+		_, err := client.AppendToStream(ctx, "breaker", kurrentdb.AppendToStreamOptions{}, fakeEvent("recovered"))
+		require.NoError(t, err)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		require.NoError(t, listener.Close())
+
+		// Both zero and -1 disable the circuit breaker.
+		for _, cooldown := range []string{"0", "-1"} {
+			config, err := kurrentdb.ParseConnectionString(fmt.Sprintf(
+				"kurrentdb://%s?tls=false&maxDiscoverAttempts=1&circuitBreakerCooldown=%s", address, cooldown))
+			require.NoError(t, err)
+			config.Logger = kurrentdb.NoopLogging()
+
+			client, err := kurrentdb.NewClient(config)
+			require.NoError(t, err)
+
+			for i := 0; i < 2; i++ {
+				err := client.Connect(ctx)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "maximum discovery attempt count reached", cooldown+" "+strconv.Itoa(i))
+			}
+
+			client.Close()
+		}
+	})
+}
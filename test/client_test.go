@@ -24,4 +24,21 @@ func TestMisc(t *testing.T) {
 	t.Run("ConnectionString", TestConnectionStringSuite)
 	t.Run("PositionParsing", TestPositionParsingSuite)
 	t.Run("UuidParsing", TestUUIDParsingSuite)
+	t.Run("FileCheckpointStore", TestFileCheckpointStore)
+	t.Run("Serializer", TestSerializer)
+	t.Run("FakeServer", TestFakeServer)
+	t.Run("Telemetry", TestTelemetry)
+	t.Run("Logging", TestLogging)
+	t.Run("ConnectionEvents", TestConnectionEvents)
+	t.Run("TopologyRefresh", TestTopologyRefresh)
+	t.Run("ConnectionPool", TestConnectionPool)
+	t.Run("ClusterInfo", TestClusterInfo)
+	t.Run("Discovery", TestDiscovery)
+	t.Run("DnsDiscovery", TestDnsDiscovery)
+	t.Run("CircuitBreaker", TestCircuitBreaker)
+	t.Run("ConfigurationSources", TestConfigurationSources)
+	t.Run("CredentialsProviders", TestCredentialsProviders)
+	t.Run("TokenSources", TestTokenSources)
+	t.Run("CallCredentials", TestCallCredentials)
+	t.Run("AggregateRepository", TestAggregateRepository)
 }
@@ -0,0 +1,106 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdbtest"
+)
+
+func nextClusterChange(t *testing.T, changes <-chan kurrentdb.ClusterChange) kurrentdb.ClusterChange {
+	select {
+	case change, ok := <-changes:
+		require.True(t, ok, "cluster changes channel closed")
+		require.NoError(t, change.Error)
+		return change
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for a cluster change")
+		return kurrentdb.ClusterChange{}
+	}
+}
+
+func TestClusterInfo(t *testing.T) {
+	server, err := kurrentdbtest.NewServer()
+	require.NoError(t, err)
+	defer server.Close()
+
+	client, err := server.NewClient()
+	require.NoError(t, err)
+	defer client.Close()
+
+	server.SetGossip(
+		kurrentdbtest.Member{Addr: "10.0.0.1:2113", State: kurrentdb.NodeStateLeader},
+		kurrentdbtest.Member{Addr: "10.0.0.2:2113", State: kurrentdb.NodeStateFollower},
+		kurrentdbtest.Member{Addr: "10.0.0.3:2113", State: kurrentdb.NodeStateFollower, Dead: true},
+	)
+
+	t.Run("readCluster", func(t *testing.T) {
+		cluster, err := client.ReadCluster(context.Background())
+		require.NoError(t, err)
+		require.Len(t, cluster.Members, 3)
+
+		leader, ok := cluster.Leader()
+		require.True(t, ok)
+		assert.Equal(t, kurrentdb.EndPoint{Host: "10.0.0.1", Port: 2113}, leader.HttpEndPoint)
+		assert.True(t, leader.IsAlive)
+		assert.NotEqual(t, leader.InstanceId, cluster.Members[1].InstanceId)
+		assert.WithinDuration(t, time.Now(), leader.TimeStamp, time.Minute)
+
+		followers := cluster.Followers()
+		require.Len(t, followers, 1)
+		assert.Equal(t, "10.0.0.2:2113", followers[0].HttpEndPoint.String())
+
+		member, ok := cluster.Member(cluster.Members[2].InstanceId)
+		require.True(t, ok)
+		assert.False(t, member.IsAlive)
+		assert.Empty(t, cluster.ReadOnlyReplicas())
+	})
+
+	t.Run("watchCluster", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		changes := client.WatchCluster(ctx, 20*time.Millisecond)
+
+		change := nextClusterChange(t, changes)
+		assert.Len(t, change.Added, 3)
+		assert.Empty(t, change.Changed)
+
+		server.SetGossip(
+			kurrentdbtest.Member{Addr: "10.0.0.1:2113", State: kurrentdb.NodeStateFollower},
+			kurrentdbtest.Member{Addr: "10.0.0.2:2113", State: kurrentdb.NodeStateLeader},
+		)
+
+		change = nextClusterChange(t, changes)
+		assert.Empty(t, change.Added)
+		require.Len(t, change.Removed, 1)
+		assert.Equal(t, "10.0.0.3:2113", change.Removed[0].HttpEndPoint.String())
+		require.Len(t, change.Changed, 2)
+		assert.Equal(t, kurrentdb.NodeStateLeader, change.Changed[0].Previous.State)
+		assert.Equal(t, kurrentdb.NodeStateFollower, change.Changed[0].Current.State)
+
+		leader, ok := change.Cluster.Leader()
+		require.True(t, ok)
+		assert.Equal(t, "10.0.0.2:2113", leader.HttpEndPoint.String())
+
+		cancel()
+		for range changes {
+		}
+	})
+
+	t.Run("watchClusterDefaultInterval", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		changes := client.WatchCluster(ctx, 0)
+
+		change := nextClusterChange(t, changes)
+		assert.NoError(t, change.Error)
+		assert.Len(t, change.Added, 2)
+
+		cancel()
+		for range changes {
+		}
+	})
+}
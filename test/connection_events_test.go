@@ -0,0 +1,118 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdbtest"
+)
+
+func nextConnectionEvent(t *testing.T, events <-chan *kurrentdb.ConnectionEvent) *kurrentdb.ConnectionEvent {
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "connection events channel closed")
+		return event
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for a connection event")
+		return nil
+	}
+}
+
+func requireConnectionEventsClosed(t *testing.T, events <-chan *kurrentdb.ConnectionEvent) {
+	select {
+	case _, ok := <-events:
+		require.False(t, ok, "expected the connection events channel to be closed")
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for the connection events channel to close")
+	}
+}
+
+func TestConnectionEvents(t *testing.T) {
+	t.Run("connectAndClose", func(t *testing.T) {
+		server, err := kurrentdbtest.NewServer()
+		require.NoError(t, err)
+		defer server.Close()
+
+		client, err := server.NewClient()
+		require.NoError(t, err)
+
+		events := client.WatchConnection(context.Background())
+
+		_, err = client.AppendToStream(context.Background(), "connection", kurrentdb.AppendToStreamOptions{}, fakeEvent("connected"))
+		require.NoError(t, err)
+
+		require.NotNil(t, nextConnectionEvent(t, events).Discovering)
+
+		connected := nextConnectionEvent(t, events).Connected
+		require.NotNil(t, connected)
+		assert.Equal(t, server.Addr(), connected.Endpoint.String())
+		assert.Equal(t, 26, connected.ServerVersion.Major)
+		assert.Equal(t, kurrentdb.NodeStateUnknown, connected.NodeState)
+
+		late := client.WatchConnection(context.Background())
+		require.NotNil(t, nextConnectionEvent(t, late).Connected)
+
+		require.NoError(t, client.Close())
+		require.NotNil(t, nextConnectionEvent(t, events).Closed)
+		requireConnectionEventsClosed(t, events)
+		require.NotNil(t, nextConnectionEvent(t, late).Closed)
+		requireConnectionEventsClosed(t, late)
+
+		closed := client.WatchConnection(context.Background())
+		require.NotNil(t, nextConnectionEvent(t, closed).Closed)
+		requireConnectionEventsClosed(t, closed)
+	})
+
+	t.Run("discoveryFailure", func(t *testing.T) {
+		server, err := kurrentdbtest.NewServer()
+		require.NoError(t, err)
+		address := server.Addr()
+		server.Close()
+
+		config, err := kurrentdb.ParseConnectionString(fmt.Sprintf("kurrentdb://%s?tls=false&maxDiscoverAttempts=1", address))
+		require.NoError(t, err)
+		config.Logger = kurrentdb.NoopLogging()
+
+		client, err := kurrentdb.NewClient(config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		events := client.WatchConnection(context.Background())
+
+		_, err = client.AppendToStream(context.Background(), "connection", kurrentdb.AppendToStreamOptions{}, fakeEvent("lost"))
+		require.Error(t, err)
+
+		require.NotNil(t, nextConnectionEvent(t, events).Discovering)
+
+		disconnected := nextConnectionEvent(t, events).Disconnected
+		require.NotNil(t, disconnected)
+		assert.Error(t, disconnected.Error)
+
+		// The client stays usable after a failed discovery and only closes when asked to.
+		require.NoError(t, client.Close())
+		require.NotNil(t, nextConnectionEvent(t, events).Closed)
+		requireConnectionEventsClosed(t, events)
+	})
+
+	t.Run("cancelledWatch", func(t *testing.T) {
+		server, err := kurrentdbtest.NewServer()
+		require.NoError(t, err)
+		defer server.Close()
+
+		client, err := server.NewClient()
+		require.NoError(t, err)
+		defer client.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events := client.WatchConnection(ctx)
+		cancel()
+
+		requireConnectionEventsClosed(t, events)
+	})
+}
@@ -0,0 +1,203 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdbtest"
+)
+
+func TestConnectionPool(t *testing.T) {
+	leader, err := kurrentdbtest.NewServer()
+	require.NoError(t, err)
+	defer leader.Close()
+
+	follower, err := kurrentdbtest.NewServer()
+	require.NoError(t, err)
+	defer follower.Close()
+
+	members := []kurrentdbtest.Member{
+		{Addr: leader.Addr(), State: kurrentdb.NodeStateLeader},
+		{Addr: follower.Addr(), State: kurrentdb.NodeStateFollower},
+	}
+
+	leader.SetGossip(members...)
+	follower.SetGossip(members...)
+
+	leaderClient, err := leader.NewClient()
+	require.NoError(t, err)
+	defer leaderClient.Close()
+
+	followerClient, err := follower.NewClient()
+	require.NoError(t, err)
+	defer followerClient.Close()
+
+	newClient := func(t *testing.T, nodePreference string) *kurrentdb.Client {
+		config, err := kurrentdb.ParseConnectionString(fmt.Sprintf(
+			"kurrentdb://%s,%s?tls=false&nodePreference=%s", leader.Addr(), follower.Addr(), nodePreference))
+		require.NoError(t, err)
+		config.Logger = kurrentdb.NoopLogging()
+		// Credentials tell which node received a call, as the servers record them.
+		config.InsecureCredentials = kurrentdb.InsecureCredentialsAllow
+
+		client, err := kurrentdb.NewClient(config)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = client.Close()
+		})
+
+		return client
+	}
+
+	ctx := context.Background()
+
+	t.Run("readsRoutedToPreferredNode", func(t *testing.T) {
+		client := newClient(t, "leader")
+		streamID := uuid.NewString()
+
+		_, err := followerClient.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{}, fakeEvent("on-follower"))
+		require.NoError(t, err)
+
+		stream, err := client.ReadStream(ctx, streamID, kurrentdb.ReadStreamOptions{NodePreference: kurrentdb.NodePreferenceFollower}, 10)
+		require.NoError(t, err)
+		events := readFakeStream(t, stream)
+		require.Len(t, events, 1)
+		assert.Equal(t, "on-follower", events[0].Event.EventType)
+
+		stream, err = client.ReadStream(ctx, streamID, kurrentdb.ReadStreamOptions{}, 10)
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		stream.Close()
+		var esErr *kurrentdb.Error
+		require.True(t, errors.As(err, &esErr))
+		assert.Equal(t, kurrentdb.ErrorCodeResourceNotFound, esErr.Code())
+	})
+
+	t.Run("subscriptionsRoutedToPreferredNode", func(t *testing.T) {
+		client := newClient(t, "leader")
+
+		subscription, err := client.SubscribeToAll(ctx, kurrentdb.SubscribeToAllOptions{
+			From:           kurrentdb.Start{},
+			NodePreference: kurrentdb.NodePreferenceFollower,
+		})
+		require.NoError(t, err)
+		defer subscription.Close()
+
+		_, err = followerClient.AppendToStream(ctx, uuid.NewString(), kurrentdb.AppendToStreamOptions{}, fakeEvent("subscribed"))
+		require.NoError(t, err)
+
+		for {
+			event := subscription.Recv()
+			require.Nil(t, event.SubscriptionDropped)
+
+			if event.EventAppeared != nil && event.EventAppeared.Event.EventType == "subscribed" {
+				return
+			}
+		}
+	})
+
+	t.Run("writesRoutedToLeader", func(t *testing.T) {
+		client := newClient(t, "follower")
+		streamID := uuid.NewString()
+
+		_, err := client.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{}, fakeEvent("written"))
+		require.NoError(t, err)
+
+		stream, err := leaderClient.ReadStream(ctx, streamID, kurrentdb.ReadStreamOptions{}, 10)
+		require.NoError(t, err)
+		require.Len(t, readFakeStream(t, stream), 1)
+
+		stream, err = client.ReadStream(ctx, streamID, kurrentdb.ReadStreamOptions{NodePreference: kurrentdb.NodePreferenceLeader}, 10)
+		require.NoError(t, err)
+		require.Len(t, readFakeStream(t, stream), 1)
+	})
+
+	t.Run("persistentSubscriptionsRoutedToLeader", func(t *testing.T) {
+		client := newClient(t, "follower")
+		opts := kurrentdb.DeletePersistentSubscriptionOptions{
+			Authenticated: &kurrentdb.Credentials{Login: "ops", Password: "secret"},
+		}
+
+		// The test server doesn't implement persistent subscriptions, the call fails once it reached a node.
+		err := client.DeletePersistentSubscription(ctx, uuid.NewString(), "group", opts)
+		require.Error(t, err)
+		assert.Equal(t, "Basic b3BzOnNlY3JldA==", leader.Authorization())
+		assert.Empty(t, follower.Authorization())
+	})
+
+	t.Run("unresponsivePreferredNodeDoesNotBlockOtherOperations", func(t *testing.T) {
+		// Accepts connections but never answers, so connecting to it lasts until the gossip timeout.
+		unresponsive, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer unresponsive.Close()
+
+		go func() {
+			for {
+				conn, err := unresponsive.Accept()
+				if err != nil {
+					return
+				}
+
+				defer conn.Close()
+			}
+		}()
+
+		server, err := kurrentdbtest.NewServer()
+		require.NoError(t, err)
+		defer server.Close()
+
+		server.SetGossip(
+			kurrentdbtest.Member{Addr: server.Addr(), State: kurrentdb.NodeStateLeader},
+			kurrentdbtest.Member{Addr: unresponsive.Addr().String(), State: kurrentdb.NodeStateFollower},
+		)
+
+		config, err := kurrentdb.ParseConnectionString(fmt.Sprintf(
+			"kurrentdb://%s,%s?tls=false&nodePreference=leader&gossipTimeout=2", server.Addr(), server.Addr()))
+		require.NoError(t, err)
+		config.Logger = kurrentdb.NoopLogging()
+
+		client, err := kurrentdb.NewClient(config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		streamID := uuid.NewString()
+		_, err = client.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{}, fakeEvent("first"))
+		require.NoError(t, err)
+
+		read := make(chan error, 1)
+		go func() {
+			stream, err := client.ReadStream(ctx, streamID, kurrentdb.ReadStreamOptions{NodePreference: kurrentdb.NodePreferenceFollower}, 10)
+			if err == nil {
+				_, err = stream.Recv()
+				stream.Close()
+			}
+
+			read <- err
+		}()
+
+		// Give the follower read time to start connecting.
+		time.Sleep(100 * time.Millisecond)
+
+		started := time.Now()
+		_, err = client.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{}, fakeEvent("second"))
+		require.NoError(t, err)
+		assert.Less(t, time.Since(started), time.Second)
+
+		// Once connecting times out, the follower read falls back to the main connection.
+		select {
+		case err := <-read:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the follower read never completed")
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdbtest"
+)
+
+// rewriteFile replaces the content of a file, moving its modification time forward so that the change is noticed
+// whatever the resolution of the file system's clock.
+func rewriteFile(t *testing.T, path string, content []byte) {
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+
+	modTime := time.Now()
+	if info, err := os.Stat(path); err == nil && !info.ModTime().Before(modTime) {
+		modTime = info.ModTime()
+	}
+
+	modTime = modTime.Add(time.Second)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func certificateCommonName(t *testing.T, certificate *tls.Certificate) string {
+	parsed, err := x509.ParseCertificate(certificate.Certificate[0])
+	require.NoError(t, err)
+	return parsed.Subject.CommonName
+}
+
+func TestCredentialsProviders(t *testing.T) {
+	t.Run("fileCertificateProviderReloads", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile := filepath.Join(dir, "user.crt"), filepath.Join(dir, "user.key")
+		provider := kurrentdb.NewFileCertificateProvider(certFile, keyFile)
+
+		_, err := provider.Certificate()
+		require.Error(t, err)
+
+		certPEM, keyPEM := selfSignedCertificatePEM(t, "alice")
+		rewriteFile(t, certFile, certPEM)
+		rewriteFile(t, keyFile, keyPEM)
+
+		certificate, err := provider.Certificate()
+		require.NoError(t, err)
+		assert.Equal(t, "alice", certificateCommonName(t, certificate))
+
+		// Halfway through a rotation, the certificate and the key don't match: the previous certificate is kept.
+		certPEM, keyPEM = selfSignedCertificatePEM(t, "bob")
+		rewriteFile(t, certFile, certPEM)
+
+		certificate, err = provider.Certificate()
+		require.NoError(t, err)
+		assert.Equal(t, "alice", certificateCommonName(t, certificate))
+
+		rewriteFile(t, keyFile, keyPEM)
+
+		certificate, err = provider.Certificate()
+		require.NoError(t, err)
+		assert.Equal(t, "bob", certificateCommonName(t, certificate))
+	})
+
+	t.Run("fileCredentialsProviderReloads", func(t *testing.T) {
+		dir := t.TempDir()
+		loginFile, passwordFile := filepath.Join(dir, "login"), filepath.Join(dir, "password")
+		provider := kurrentdb.NewFileCredentialsProvider(loginFile, passwordFile)
+
+		_, err := provider.Credentials(context.Background())
+		require.Error(t, err)
+
+		rewriteFile(t, loginFile, []byte("admin\n"))
+		rewriteFile(t, passwordFile, []byte("changeit\n"))
+
+		credentials, err := provider.Credentials(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, kurrentdb.Credentials{Login: "admin", Password: "changeit"}, *credentials)
+
+		rewriteFile(t, passwordFile, []byte("rotated"))
+
+		credentials, err = provider.Credentials(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, kurrentdb.Credentials{Login: "admin", Password: "rotated"}, *credentials)
+
+		// A file briefly missing during a rotation doesn't fail calls.
+		require.NoError(t, os.Remove(passwordFile))
+
+		credentials, err = provider.Credentials(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "rotated", credentials.Password)
+	})
+
+	t.Run("rotationWithoutRecreatingClient", func(t *testing.T) {
+		serverCertPEM, serverKeyPEM := selfSignedCertificatePEM(t, "server")
+		serverCertificate, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+		require.NoError(t, err)
+
+		var mu sync.Mutex
+		var presented []string
+		server, err := kurrentdbtest.NewTLSServer(&tls.Config{
+			Certificates: []tls.Certificate{serverCertificate},
+			ClientAuth:   tls.RequireAnyClientCert,
+			VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+				certificate, err := x509.ParseCertificate(rawCerts[0])
+				if err != nil {
+					return err
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				presented = append(presented, certificate.Subject.CommonName)
+				return nil
+			},
+		})
+		require.NoError(t, err)
+		defer server.Close()
+
+		lastPresented := func() string {
+			mu.Lock()
+			defer mu.Unlock()
+			return presented[len(presented)-1]
+		}
+
+		dir := t.TempDir()
+		certFile, keyFile := filepath.Join(dir, "user.crt"), filepath.Join(dir, "user.key")
+		certPEM, keyPEM := selfSignedCertificatePEM(t, "alice")
+		rewriteFile(t, certFile, certPEM)
+		rewriteFile(t, keyFile, keyPEM)
+
+		config, err := kurrentdb.ParseConnectionString(server.ConnectionString() + "?userCertFile=" + certFile + "&userKeyFile=" + keyFile)
+		require.NoError(t, err)
+		config.Logger = kurrentdb.NoopLogging()
+		config.RootCAs = x509.NewCertPool()
+		require.True(t, config.RootCAs.AppendCertsFromPEM(serverCertPEM))
+
+		var credentialsMu sync.Mutex
+		password := "one"
+		config.CredentialsProvider = kurrentdb.CredentialsProviderFunc(func(context.Context) (*kurrentdb.Credentials, error) {
+			credentialsMu.Lock()
+			defer credentialsMu.Unlock()
+			return &kurrentdb.Credentials{Login: "admin", Password: password}, nil
+		})
+
+		client, err := kurrentdb.NewClient(config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		appendEvent := func() error {
+			_, err := client.AppendToStream(context.Background(), "rotation", kurrentdb.AppendToStreamOptions{}, kurrentdb.EventData{
+				EventID:     uuid.New(),
+				EventType:   "Rotated",
+				ContentType: kurrentdb.ContentTypeBinary,
+			})
+			return err
+		}
+
+		basic := func(login, password string) string {
+			return "Basic " + base64.StdEncoding.EncodeToString([]byte(login+":"+password))
+		}
+
+		require.NoError(t, appendEvent())
+		assert.Equal(t, "alice", lastPresented())
+		assert.Equal(t, basic("admin", "one"), server.Authorization())
+
+		// New credentials are sent by the next call, on the same connection.
+		credentialsMu.Lock()
+		password = "two"
+		credentialsMu.Unlock()
+
+		require.NoError(t, appendEvent())
+		assert.Equal(t, basic("admin", "two"), server.Authorization())
+
+		// A rotated certificate is presented by the next connection.
+		certPEM, keyPEM = selfSignedCertificatePEM(t, "bob")
+		rewriteFile(t, certFile, certPEM)
+		rewriteFile(t, keyFile, keyPEM)
+		server.DropConnections()
+
+		require.Eventually(t, func() bool {
+			return appendEvent() == nil
+		}, 10*time.Second, 50*time.Millisecond)
+		assert.Equal(t, "bob", lastPresented())
+	})
+}
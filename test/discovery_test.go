@@ -0,0 +1,152 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdbtest"
+)
+
+// newBlackhole returns the address of a listener that accepts connections but never answers, like a node stuck behind
+// a dropping firewall.
+func newBlackhole(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			t.Cleanup(func() {
+				_ = conn.Close()
+			})
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func requireErrorCode(t *testing.T, err error, code kurrentdb.ErrorCode) {
+	var esErr *kurrentdb.Error
+	require.True(t, errors.As(err, &esErr), "expected a kurrentdb.Error, got %v", err)
+	assert.Equal(t, code, esErr.Code(), esErr.Error())
+}
+
+func TestDiscovery(t *testing.T) {
+	newClient := func(t *testing.T, connectionString string) *kurrentdb.Client {
+		config, err := kurrentdb.ParseConnectionString(connectionString)
+		require.NoError(t, err)
+		config.Logger = kurrentdb.NoopLogging()
+
+		client, err := kurrentdb.NewClient(config)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = client.Close()
+		})
+
+		return client
+	}
+
+	t.Run("connect", func(t *testing.T) {
+		server, err := kurrentdbtest.NewServer()
+		require.NoError(t, err)
+		defer server.Close()
+
+		client := newClient(t, server.ConnectionString())
+		events := client.WatchConnection(context.Background())
+
+		require.NoError(t, client.Connect(context.Background()))
+		require.NotNil(t, nextConnectionEvent(t, events).Discovering)
+		require.NotNil(t, nextConnectionEvent(t, events).Connected)
+
+		require.NoError(t, client.Connect(context.Background()))
+	})
+
+	t.Run("connectHonoursDeadline", func(t *testing.T) {
+		client := newClient(t, fmt.Sprintf("kurrentdb://%s?tls=false&gossipTimeout=30", newBlackhole(t)))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := client.Connect(ctx)
+		assert.Less(t, time.Since(start), 5*time.Second)
+		requireErrorCode(t, err, kurrentdb.ErrorCodeDeadlineExceeded)
+
+		// The client is still usable, the next operation starts a new discovery process.
+		ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		requireErrorCode(t, client.Connect(ctx), kurrentdb.ErrorCodeDeadlineExceeded)
+	})
+
+	t.Run("operationsHonourDeadline", func(t *testing.T) {
+		blackhole := newBlackhole(t)
+		client := newClient(t, fmt.Sprintf("kurrentdb://%s,%s?tls=false&gossipTimeout=30", blackhole, blackhole))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		_, err := client.ReadStream(ctx, "discovery", kurrentdb.ReadStreamOptions{}, 1)
+		requireErrorCode(t, err, kurrentdb.ErrorCodeDeadlineExceeded)
+	})
+
+	t.Run("operationDeadlineBoundsDiscovery", func(t *testing.T) {
+		blackhole := newBlackhole(t)
+		client := newClient(t, fmt.Sprintf("kurrentdb://%s,%s?tls=false&gossipTimeout=30", blackhole, blackhole))
+
+		deadline := 200 * time.Millisecond
+		start := time.Now()
+		_, err := client.DeleteStream(context.Background(), "discovery", kurrentdb.DeleteStreamOptions{Deadline: &deadline})
+		assert.Less(t, time.Since(start), 5*time.Second)
+		requireErrorCode(t, err, kurrentdb.ErrorCodeDeadlineExceeded)
+	})
+
+	t.Run("defaultDeadlineBoundsDiscovery", func(t *testing.T) {
+		blackhole := newBlackhole(t)
+		client := newClient(t, fmt.Sprintf("kurrentdb://%s,%s?tls=false&gossipTimeout=30&defaultDeadline=200", blackhole, blackhole))
+
+		start := time.Now()
+		_, err := client.GetServerVersion()
+		assert.Less(t, time.Since(start), 5*time.Second)
+		requireErrorCode(t, err, kurrentdb.ErrorCodeDeadlineExceeded)
+	})
+
+	t.Run("connectCancelled", func(t *testing.T) {
+		client := newClient(t, fmt.Sprintf("kurrentdb://%s?tls=false", newBlackhole(t)))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+
+		err := client.Connect(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("givesUpAfterMaxAttempts", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		require.NoError(t, listener.Close())
+
+		client := newClient(t, fmt.Sprintf("kurrentdb://%s?tls=false&maxDiscoverAttempts=3&discoveryInterval=10", address))
+
+		err = client.Connect(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "maximum discovery attempt count reached")
+
+		requireErrorCode(t, client.Connect(context.Background()), kurrentdb.ErrorCodeCircuitOpen)
+	})
+}
@@ -0,0 +1,207 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdbtest"
+)
+
+// stubDNS is a DNS server answering A and SRV queries out of a table, for names under the .test domain.
+type stubDNS struct {
+	conn    net.PacketConn
+	mu      sync.Mutex
+	a       map[string][]net.IP
+	srv     map[string][]net.SRV
+	queries int32
+}
+
+func newStubDNS(t *testing.T) *stubDNS {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	server := &stubDNS{
+		conn: conn,
+		a:    make(map[string][]net.IP),
+		srv:  make(map[string][]net.SRV),
+	}
+
+	go server.serve()
+	return server
+}
+
+func (server *stubDNS) setA(name string, ips ...net.IP) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.a[name+"."] = ips
+}
+
+func (server *stubDNS) setSRV(name string, records ...net.SRV) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.srv[name+"."] = records
+}
+
+func (server *stubDNS) resolver() *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "udp", server.conn.LocalAddr().String())
+		},
+	}
+}
+
+func (server *stubDNS) serve() {
+	buffer := make([]byte, 512)
+
+	for {
+		n, addr, err := server.conn.ReadFrom(buffer)
+		if err != nil {
+			return
+		}
+
+		var request dnsmessage.Message
+		if err := request.Unpack(buffer[:n]); err != nil || len(request.Questions) != 1 {
+			continue
+		}
+
+		atomic.AddInt32(&server.queries, 1)
+		response := server.answer(request)
+		packed, err := response.Pack()
+		if err != nil {
+			continue
+		}
+
+		_, _ = server.conn.WriteTo(packed, addr)
+	}
+}
+
+func (server *stubDNS) answer(request dnsmessage.Message) dnsmessage.Message {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	question := request.Questions[0]
+	response := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: request.ID, Response: true, Authoritative: true},
+		Questions: request.Questions,
+	}
+
+	name := question.Name.String()
+	header := dnsmessage.ResourceHeader{Name: question.Name, Type: question.Type, Class: dnsmessage.ClassINET, TTL: 1}
+	ips, hasA := server.a[name]
+	records, hasSRV := server.srv[name]
+
+	switch {
+	case question.Type == dnsmessage.TypeA && hasA:
+		for _, ip := range ips {
+			var a dnsmessage.AResource
+			copy(a.A[:], ip.To4())
+			response.Answers = append(response.Answers, dnsmessage.Resource{Header: header, Body: &a})
+		}
+	case question.Type == dnsmessage.TypeSRV && hasSRV:
+		for _, record := range records {
+			response.Answers = append(response.Answers, dnsmessage.Resource{Header: header, Body: &dnsmessage.SRVResource{
+				Priority: record.Priority,
+				Weight:   record.Weight,
+				Port:     record.Port,
+				Target:   dnsmessage.MustNewName(record.Target),
+			}})
+		}
+	case !hasA && !hasSRV:
+		response.RCode = dnsmessage.RCodeNameError
+	}
+
+	return response
+}
+
+func TestDnsDiscovery(t *testing.T) {
+	server, err := kurrentdbtest.NewServer()
+	require.NoError(t, err)
+	defer server.Close()
+
+	host, portString, err := net.SplitHostPort(server.Addr())
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portString)
+	require.NoError(t, err)
+
+	dns := newStubDNS(t)
+
+	newClient := func(t *testing.T, connectionString string) *kurrentdb.Client {
+		config, err := kurrentdb.ParseConnectionString(connectionString)
+		require.NoError(t, err)
+		config.Logger = kurrentdb.NoopLogging()
+		config.Resolver = dns.resolver()
+
+		client, err := kurrentdb.NewClient(config)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = client.Close()
+		})
+
+		return client
+	}
+
+	connectedEndpoint := func(t *testing.T, client *kurrentdb.Client) string {
+		events := client.WatchConnection(context.Background())
+		require.NoError(t, client.Connect(context.Background()))
+		return nextConnectedEvent(t, events).Endpoint.String()
+	}
+
+	t.Run("srvRecords", func(t *testing.T) {
+		dns.setSRV("_kurrentdb._tcp.srv.cluster.test", net.SRV{Target: "localhost.", Port: uint16(port), Priority: 10, Weight: 1})
+
+		client := newClient(t, "kurrentdb+discover://srv.cluster.test?tls=false")
+		assert.Equal(t, server.Addr(), connectedEndpoint(t, client))
+	})
+
+	t.Run("srvRecordName", func(t *testing.T) {
+		dns.setSRV("_grpc._tcp.named.cluster.test", net.SRV{Target: "localhost.", Port: uint16(port), Priority: 10, Weight: 1})
+
+		client := newClient(t, "kurrentdb+discover://_grpc._tcp.named.cluster.test?tls=false")
+		assert.Equal(t, server.Addr(), connectedEndpoint(t, client))
+	})
+
+	t.Run("aRecords", func(t *testing.T) {
+		dns.setA("a.cluster.test", net.ParseIP(host))
+
+		client := newClient(t, fmt.Sprintf("kurrentdb+discover://a.cluster.test:%d?tls=false", port))
+		assert.Equal(t, server.Addr(), connectedEndpoint(t, client))
+	})
+
+	t.Run("resolvedAgainOnEveryAttempt", func(t *testing.T) {
+		client := newClient(t, fmt.Sprintf(
+			"kurrentdb+discover://late.cluster.test:%d?tls=false&maxDiscoverAttempts=100&discoveryInterval=10", port))
+
+		queries := atomic.LoadInt32(&dns.queries)
+		events := client.WatchConnection(context.Background())
+		connected := make(chan error, 1)
+		go func() {
+			connected <- client.Connect(context.Background())
+		}()
+
+		require.NotNil(t, nextConnectionEvent(t, events).Discovering)
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&dns.queries) > queries+4
+		}, 5*time.Second, 10*time.Millisecond)
+
+		dns.setA("late.cluster.test", net.ParseIP(host))
+
+		require.NoError(t, <-connected)
+		assert.Equal(t, server.Addr(), nextConnectedEvent(t, events).Endpoint.String())
+	})
+}
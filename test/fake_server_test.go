@@ -0,0 +1,319 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdbtest"
+)
+
+func fakeEvent(eventType string) kurrentdb.EventData {
+	return kurrentdb.EventData{
+		EventID:     uuid.New(),
+		EventType:   eventType,
+		ContentType: kurrentdb.ContentTypeJson,
+		Data:        []byte(`{"foo":"bar"}`),
+	}
+}
+
+func readFakeStream(t *testing.T, stream *kurrentdb.ReadStream) []*kurrentdb.ResolvedEvent {
+	defer stream.Close()
+
+	events := make([]*kurrentdb.ResolvedEvent, 0)
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return events
+		}
+
+		require.NoError(t, err)
+		events = append(events, event)
+	}
+}
+
+func TestFakeServer(t *testing.T) {
+	server, err := kurrentdbtest.NewServer()
+	require.NoError(t, err)
+	defer server.Close()
+
+	client, err := server.NewClient()
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx := context.Background()
+
+	t.Run("appendAndReadStream", func(t *testing.T) {
+		streamID := uuid.NewString()
+		result, err := client.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{StreamState: kurrentdb.NoStream{}}, fakeEvent("first"), fakeEvent("second"))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), result.NextExpectedVersion)
+
+		stream, err := client.ReadStream(ctx, streamID, kurrentdb.ReadStreamOptions{}, 10)
+		require.NoError(t, err)
+		events := readFakeStream(t, stream)
+		require.Len(t, events, 2)
+		assert.Equal(t, "first", events[0].Event.EventType)
+		assert.Equal(t, uint64(1), events[1].Event.EventNumber)
+		assert.Equal(t, "application/json", events[1].Event.ContentType)
+
+		stream, err = client.ReadStream(ctx, streamID, kurrentdb.ReadStreamOptions{Direction: kurrentdb.Backwards, From: kurrentdb.End{}}, 1)
+		require.NoError(t, err)
+		events = readFakeStream(t, stream)
+		require.Len(t, events, 1)
+		assert.Equal(t, "second", events[0].Event.EventType)
+	})
+
+	t.Run("wrongExpectedVersion", func(t *testing.T) {
+		streamID := uuid.NewString()
+		_, err := client.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{}, fakeEvent("first"))
+		require.NoError(t, err)
+
+		_, err = client.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{StreamState: kurrentdb.NoStream{}}, fakeEvent("second"))
+		require.Error(t, err)
+		esErr, ok := kurrentdb.FromError(err)
+		require.False(t, ok)
+		assert.Equal(t, kurrentdb.ErrorCodeWrongExpectedVersion, esErr.Code())
+
+		_, err = client.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{StreamState: kurrentdb.Revision(0)}, fakeEvent("second"))
+		require.NoError(t, err)
+	})
+
+	t.Run("readAllPreservesWriteOrder", func(t *testing.T) {
+		server.Reset()
+
+		for i := 0; i < 3; i++ {
+			_, err := client.AppendToStream(ctx, fmt.Sprintf("stream-%d", i), kurrentdb.AppendToStreamOptions{}, fakeEvent(fmt.Sprintf("event-%d", i)))
+			require.NoError(t, err)
+		}
+
+		stream, err := client.ReadAll(ctx, kurrentdb.ReadAllOptions{}, 10)
+		require.NoError(t, err)
+		events := readFakeStream(t, stream)
+		require.Len(t, events, 3)
+
+		for i, event := range events {
+			assert.Equal(t, fmt.Sprintf("event-%d", i), event.Event.EventType)
+			if i > 0 {
+				assert.True(t, events[i-1].Event.Position.Commit < event.Event.Position.Commit)
+			}
+		}
+	})
+
+	t.Run("deleteAndTombstone", func(t *testing.T) {
+		streamID := uuid.NewString()
+		_, err := client.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{}, fakeEvent("first"))
+		require.NoError(t, err)
+
+		_, err = client.DeleteStream(ctx, streamID, kurrentdb.DeleteStreamOptions{StreamState: kurrentdb.Revision(0)})
+		require.NoError(t, err)
+
+		stream, err := client.ReadStream(ctx, streamID, kurrentdb.ReadStreamOptions{}, 10)
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		esErr, ok := kurrentdb.FromError(err)
+		require.False(t, ok)
+		assert.Equal(t, kurrentdb.ErrorCodeResourceNotFound, esErr.Code())
+		stream.Close()
+
+		_, err = client.TombstoneStream(ctx, streamID, kurrentdb.TombstoneStreamOptions{})
+		require.NoError(t, err)
+
+		_, err = client.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{}, fakeEvent("second"))
+		esErr, ok = kurrentdb.FromError(err)
+		require.False(t, ok)
+		assert.Equal(t, kurrentdb.ErrorCodeStreamDeleted, esErr.Code())
+	})
+
+	t.Run("appendRecordsChecksConsistency", func(t *testing.T) {
+		streamID := uuid.NewString()
+		records := []kurrentdb.AppendRecord{
+			{Stream: streamID, Record: fakeEvent("first")},
+			{Stream: streamID, Record: fakeEvent("second")},
+		}
+
+		response, err := client.AppendRecords(ctx, records, kurrentdb.StreamStateCheck{Stream: streamID, ExpectedState: kurrentdb.NoStream{}})
+		require.NoError(t, err)
+		require.Len(t, response.Responses, 1)
+		assert.Equal(t, int64(1), response.Responses[0].StreamRevision)
+
+		_, err = client.AppendRecords(ctx, records, kurrentdb.StreamStateCheck{Stream: streamID, ExpectedState: kurrentdb.NoStream{}})
+		esErr, ok := kurrentdb.FromError(err)
+		require.False(t, ok)
+		assert.Equal(t, kurrentdb.ErrorCodeAppendConsistencyViolation, esErr.Code())
+
+		var violationErr *kurrentdb.AppendConsistencyViolationError
+		require.ErrorAs(t, err, &violationErr)
+		require.Len(t, violationErr.Violations, 1)
+		assert.Equal(t, kurrentdb.StreamRevision{Value: 1}, violationErr.Violations[0].ActualState)
+	})
+
+	t.Run("subscriptionReceivesLiveEvents", func(t *testing.T) {
+		streamID := uuid.NewString()
+		_, err := client.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{}, fakeEvent("before"))
+		require.NoError(t, err)
+
+		subscription, err := client.SubscribeToStream(ctx, streamID, kurrentdb.SubscribeToStreamOptions{From: kurrentdb.Start{}})
+		require.NoError(t, err)
+		defer subscription.Close()
+
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			_, _ = client.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{}, fakeEvent("after"))
+		}()
+
+		received := make([]string, 0, 2)
+		for len(received) < 2 {
+			event := subscription.Recv()
+			require.Nil(t, event.SubscriptionDropped)
+
+			if event.EventAppeared != nil {
+				received = append(received, event.EventAppeared.Event.EventType)
+			}
+		}
+
+		assert.Equal(t, []string{"before", "after"}, received)
+	})
+
+	t.Run("runnerCheckpointsWithoutHoldingUpWorkers", func(t *testing.T) {
+		prefix := uuid.NewString() + "-"
+		// Picks a stream handled by the given worker, the runner spreading streams by their FNV-1a hash.
+		streamOf := func(worker uint32) string {
+			for {
+				streamID := prefix + uuid.NewString()
+				hash := fnv.New32a()
+				_, _ = hash.Write([]byte(streamID))
+				if hash.Sum32()%2 == worker {
+					return streamID
+				}
+			}
+		}
+
+		// The first worker reports the first checkpoint, the other one handles the remaining events meanwhile.
+		_, err := client.AppendToStream(ctx, streamOf(0), kurrentdb.AppendToStreamOptions{}, fakeEvent("run"))
+		require.NoError(t, err)
+		second := streamOf(1)
+		last, err := client.AppendToStream(ctx, second, kurrentdb.AppendToStreamOptions{}, fakeEvent("run"), fakeEvent("run"), fakeEvent("run"))
+		require.NoError(t, err)
+
+		runCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		checkpointing := make(chan struct{})
+		handled := make(chan struct{})
+		var count atomic.Int32
+		var checkpoints []kurrentdb.Position
+
+		err = client.RunSubscriptionToAll(runCtx, kurrentdb.SubscriptionRunnerOptions{
+			Subscription: kurrentdb.SubscribeToAllOptions{
+				From:   kurrentdb.Start{},
+				Filter: &kurrentdb.SubscriptionFilter{Type: kurrentdb.StreamFilterType, Prefixes: []string{prefix}},
+			},
+			Workers: 2,
+			OnCheckpoint: func(position kurrentdb.Position) {
+				// The first checkpoint waits for every event to be handled, which only happens if the workers keep going.
+				if len(checkpoints) == 0 {
+					close(checkpointing)
+					select {
+					case <-handled:
+					case <-runCtx.Done():
+					}
+				}
+
+				checkpoints = append(checkpoints, position)
+				if position.Commit >= last.CommitPosition {
+					cancel()
+				}
+			},
+		}, func(ctx context.Context, event *kurrentdb.ResolvedEvent) error {
+			if event.OriginalEvent().StreamID == second {
+				select {
+				case <-checkpointing:
+				case <-ctx.Done():
+				}
+			}
+
+			if count.Add(1) == 4 {
+				close(handled)
+			}
+
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), count.Load())
+		require.NotEmpty(t, checkpoints)
+		assert.Equal(t, last.CommitPosition, checkpoints[len(checkpoints)-1].Commit)
+	})
+
+	t.Run("subscriptionResubscribesAfterConnectionDrop", func(t *testing.T) {
+		streamID := uuid.NewString()
+		_, err := client.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{}, fakeEvent("first"), fakeEvent("second"))
+		require.NoError(t, err)
+
+		subCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+
+		subscription, err := client.SubscribeToStream(subCtx, streamID, kurrentdb.SubscribeToStreamOptions{
+			From:        kurrentdb.Start{},
+			Resubscribe: &kurrentdb.ResubscribeOptions{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 100 * time.Millisecond},
+		})
+		require.NoError(t, err)
+		defer subscription.Close()
+
+		var revisions []uint64
+		var received []string
+		reconnecting, reconnected := 0, 0
+		next := func() {
+			event := subscription.Recv()
+			require.Nil(t, event.SubscriptionDropped)
+
+			switch {
+			case event.EventAppeared != nil:
+				revisions = append(revisions, event.EventAppeared.OriginalEvent().EventNumber)
+				received = append(received, event.EventAppeared.Event.EventType)
+			case event.SubscriptionReconnecting != nil:
+				reconnecting++
+				assert.Equal(t, reconnecting, event.SubscriptionReconnecting.Attempt)
+			case event.SubscriptionReconnected != nil:
+				reconnected++
+				assert.NotEmpty(t, event.SubscriptionReconnected.SubscriptionID)
+			}
+		}
+
+		for len(received) < 2 {
+			next()
+		}
+
+		server.DropConnections()
+		require.Eventually(t, func() bool {
+			_, err := client.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{}, fakeEvent("third"))
+			return err == nil
+		}, 5*time.Second, 50*time.Millisecond)
+
+		for len(received) < 3 {
+			next()
+		}
+
+		_, err = client.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{}, fakeEvent("fourth"))
+		require.NoError(t, err)
+
+		for len(received) < 4 {
+			next()
+		}
+
+		assert.GreaterOrEqual(t, reconnecting, 1)
+		assert.Equal(t, 1, reconnected)
+		assert.Equal(t, []string{"first", "second", "third", "fourth"}, received)
+		assert.Equal(t, []uint64{0, 1, 2, 3}, revisions)
+	})
+}
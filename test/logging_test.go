@@ -0,0 +1,144 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdbtest"
+)
+
+func decodeLogRecords(t *testing.T, buffer *bytes.Buffer) []map[string]any {
+	records := make([]map[string]any, 0)
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		record := make(map[string]any)
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func findLogRecord(records []map[string]any, message string) map[string]any {
+	for _, record := range records {
+		if record["msg"] == message {
+			return record
+		}
+	}
+
+	return nil
+}
+
+func TestLogging(t *testing.T) {
+	t.Run("loggingFuncHandlerFormatsAttributes", func(t *testing.T) {
+		var level kurrentdb.LogLevel
+		var message string
+		handler := kurrentdb.NewLoggingFuncHandler(func(l kurrentdb.LogLevel, format string, args ...interface{}) {
+			level = l
+			message = fmt.Sprintf(format, args...)
+		})
+
+		logger := slog.New(handler).With(kurrentdb.LogKeyConnectionId, "abc")
+		logger.WithGroup("node").Warn("discovery failed", kurrentdb.LogKeyAttempt, 2, "reason", "100% broken")
+
+		assert.Equal(t, kurrentdb.LogWarn, level)
+		assert.Equal(t, `discovery failed connection_id=abc node.attempt=2 node.reason="100% broken"`, message)
+	})
+
+	t.Run("loggingFuncReceivesArguments", func(t *testing.T) {
+		var buffer bytes.Buffer
+		log.SetOutput(&buffer)
+		defer log.SetOutput(os.Stderr)
+
+		_, err := kurrentdb.ParseConnectionString("kurrentdb://localhost:2113?tls=false&foo=bar")
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), "[warn] Unknown setting: foo")
+	})
+
+	t.Run("structuredLoggerReceivesAttributes", func(t *testing.T) {
+		server, err := kurrentdbtest.NewServer()
+		require.NoError(t, err)
+		defer server.Close()
+
+		var buffer bytes.Buffer
+		config, err := kurrentdb.ParseConnectionString(server.ConnectionString())
+		require.NoError(t, err)
+		config.StructuredLogger = slog.New(slog.NewJSONHandler(&buffer, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		client, err := kurrentdb.NewClient(config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.AppendToStream(context.Background(), "logging", kurrentdb.AppendToStreamOptions{}, fakeEvent("logged"))
+		require.NoError(t, err)
+
+		records := decodeLogRecords(t, &buffer)
+		attempt := findLogRecord(records, "discovery attempt")
+		require.NotNil(t, attempt)
+		assert.Equal(t, float64(1), attempt[kurrentdb.LogKeyAttempt])
+
+		connected := findLogRecord(records, "connected to node")
+		require.NotNil(t, connected)
+		assert.Equal(t, server.Addr(), connected[kurrentdb.LogKeyEndpoint])
+		assert.NotEmpty(t, connected[kurrentdb.LogKeyConnectionId])
+	})
+
+	t.Run("structuredLoggerReceivesDiscoveryErrors", func(t *testing.T) {
+		server, err := kurrentdbtest.NewServer()
+		require.NoError(t, err)
+		address := server.Addr()
+		server.Close()
+
+		var buffer bytes.Buffer
+		config, err := kurrentdb.ParseConnectionString(fmt.Sprintf("kurrentdb://%s?tls=false&maxDiscoverAttempts=1", address))
+		require.NoError(t, err)
+		config.StructuredLogger = slog.New(slog.NewJSONHandler(&buffer, nil))
+
+		client, err := kurrentdb.NewClient(config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.AppendToStream(context.Background(), "logging", kurrentdb.AppendToStreamOptions{}, fakeEvent("lost"))
+		require.Error(t, err)
+
+		failed := findLogRecord(decodeLogRecords(t, &buffer), "error when reading server features from the best candidate")
+		require.NotNil(t, failed)
+		assert.Equal(t, address, failed[kurrentdb.LogKeyEndpoint])
+		assert.Equal(t, float64(1), failed[kurrentdb.LogKeyAttempt])
+		assert.NotEmpty(t, failed[kurrentdb.LogKeyError])
+	})
+
+	t.Run("logLevelFiltersRecords", func(t *testing.T) {
+		server, err := kurrentdbtest.NewServer()
+		require.NoError(t, err)
+		defer server.Close()
+
+		var buffer bytes.Buffer
+		config, err := kurrentdb.ParseConnectionString(server.ConnectionString() + "&logLevel=warn")
+		require.NoError(t, err)
+		config.StructuredLogger = slog.New(slog.NewJSONHandler(&buffer, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		client, err := kurrentdb.NewClient(config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.AppendToStream(context.Background(), "logging", kurrentdb.AppendToStreamOptions{}, fakeEvent("filtered"))
+		require.NoError(t, err)
+
+		assert.Nil(t, findLogRecord(decodeLogRecords(t, &buffer), "connected to node"))
+	})
+}
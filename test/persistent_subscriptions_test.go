@@ -239,6 +239,46 @@ func (s *PersistentSubscriptionSuite) TestSubscriptionClosing() {
 	s.False(timedOut, "Timed out waiting for dropped event")
 }
 
+func (s *PersistentSubscriptionSuite) TestSubscriptionEventsIterator() {
+	streamId := s.fixture.NewStreamId()
+	s.fixture.CreateTestEvents(streamId, 10)
+
+	groupName := "Group 1"
+
+	err := s.client.CreatePersistentSubscription(context.Background(), streamId, groupName, kurrentdb.PersistentStreamSubscriptionOptions{
+		StartFrom: kurrentdb.Start{},
+	})
+	s.Require().NoError(err)
+
+	subscription, err := s.client.SubscribeToPersistentSubscription(
+		context.Background(), streamId, groupName, kurrentdb.SubscribeToPersistentSubscriptionOptions{})
+	s.Require().NoError(err)
+
+	var done sync.WaitGroup
+	done.Add(1)
+
+	go func() {
+		defer done.Done()
+		count := 0
+
+		for event, err := range subscription.Events() {
+			s.NoError(err)
+			s.NoError(subscription.Ack(event.Event))
+			count++
+
+			if count == 10 {
+				break
+			}
+		}
+	}()
+
+	timedOut := s.fixture.WaitWithTimeout(&done, 5*time.Second)
+	s.False(timedOut, "Timed out waiting for the iteration to complete")
+
+	subEvent := subscription.Recv()
+	s.NotNil(subEvent.SubscriptionDropped, "breaking out of the loop should close the subscription")
+}
+
 func (s *PersistentSubscriptionSuite) TestPersistentAllCreate() {
 	groupName := s.fixture.NewGroupId()
 
@@ -3,9 +3,12 @@ package test
 import (
 	"context"
 	"github.com/stretchr/testify/suite"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
 )
 
@@ -208,3 +211,56 @@ func (s *ReadAllTestSuite) TestReadAllEventsWithCredentialsOverride() {
 		s.T().Fatalf("Unexpected failure %+v", err)
 	}
 }
+
+func (s *ReadAllTestSuite) TestReadAllEventsWithEventTypeFilter() {
+	fixture := s.fixture
+	client := fixture.Client()
+	streamId := fixture.NewStreamId()
+	prefix := uuid.New().String() + "-"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(10)*time.Second)
+	defer cancel()
+
+	fixture.CreateTestEvents(streamId, 5)
+
+	for i := 0; i < 5; i++ {
+		event := fixture.CreateTestEvent(TestEventOptions{EventType: prefix + "TestEvent"})
+		_, err := client.AppendToStream(ctx, streamId, kurrentdb.AppendToStreamOptions{}, event)
+		s.Require().NoError(err)
+	}
+
+	opts := kurrentdb.ReadAllOptions{
+		From: kurrentdb.Start{},
+		Filter: &kurrentdb.SubscriptionFilter{
+			Type:     kurrentdb.EventFilterType,
+			Prefixes: []string{prefix},
+		},
+	}
+
+	stream, err := client.ReadAll(ctx, opts, 10)
+	s.Require().NoError(err)
+	defer stream.Close()
+
+	events, err := fixture.CollectEvents(stream)
+	s.Require().NoError(err)
+
+	s.Len(events, 5)
+	for _, event := range events {
+		s.True(strings.HasPrefix(event.OriginalEvent().EventType, prefix))
+		s.Equal(streamId, event.OriginalEvent().StreamID)
+	}
+}
+
+func (s *ReadAllTestSuite) TestReadAllWithInvalidFilter() {
+	client := s.fixture.Client()
+
+	_, err := client.ReadAll(context.Background(), kurrentdb.ReadAllOptions{
+		Filter: &kurrentdb.SubscriptionFilter{
+			Type:     kurrentdb.StreamFilterType,
+			Prefixes: []string{"foo-"},
+			Regex:    "^bar",
+		},
+	}, 10)
+
+	s.Error(err)
+}
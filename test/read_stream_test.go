@@ -184,3 +184,64 @@ func (s *ReadStreamTestSuite) TestReadStreamWithCredentialsOverride() {
 	_, err = client.AppendToStream(context.Background(), streamId, opts, fixture.CreateTestEvent())
 	assert.Error(s.T(), err)
 }
+
+func (s *ReadStreamTestSuite) TestReadStreamEventsIterator() {
+	fixture := s.fixture
+	client := fixture.Client()
+
+	streamId := fixture.NewStreamId()
+	testEvents := fixture.CreateTestEvents(streamId, 10)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.ReadStream(ctx, streamId, kurrentdb.ReadStreamOptions{}, 10)
+	s.Require().NoError(err)
+
+	count := 0
+	for event, err := range stream.Events() {
+		s.Require().NoError(err)
+		s.Equal(testEvents[count].EventID, event.OriginalEvent().EventID)
+		count++
+	}
+
+	s.Equal(10, count)
+
+	_, err = stream.Recv()
+	s.ErrorIs(err, io.EOF, "the stream should be closed once the iteration ends")
+}
+
+func (s *ReadStreamTestSuite) TestReadStreamEventsIteratorBreakClosesStream() {
+	fixture := s.fixture
+	client := fixture.Client()
+
+	streamId := fixture.NewStreamId()
+	fixture.CreateTestEvents(streamId, 10)
+
+	stream, err := client.ReadStream(context.Background(), streamId, kurrentdb.ReadStreamOptions{}, 10)
+	s.Require().NoError(err)
+
+	for _, err := range stream.Events() {
+		s.Require().NoError(err)
+		break
+	}
+
+	_, err = stream.Recv()
+	s.ErrorIs(err, io.EOF, "breaking out of the loop should close the stream")
+}
+
+func (s *ReadStreamTestSuite) TestReadStreamEventsIteratorYieldsNotFound() {
+	client := s.fixture.Client()
+
+	stream, err := client.ReadStream(context.Background(), s.fixture.NewStreamId(), kurrentdb.ReadStreamOptions{}, 1)
+	s.Require().NoError(err)
+
+	var lastErr error
+	for _, err := range stream.Events() {
+		lastErr = err
+	}
+
+	var esdbErr *kurrentdb.Error
+	s.Require().True(errors.As(lastErr, &esdbErr))
+	s.Equal(kurrentdb.ErrorCodeResourceNotFound, esdbErr.Code())
+}
@@ -0,0 +1,149 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdbtest"
+	"github.com/kurrent-io/KurrentDB-Client-Go/protos/kurrentdb/protocols/v1/shared"
+)
+
+type orderPlaced struct {
+	OrderId string  `json:"orderId"`
+	Total   float64 `json:"total"`
+}
+
+func TestSerializer(t *testing.T) {
+	serializer := kurrentdb.NewSerializer()
+	require.NoError(t, kurrentdb.RegisterEvent[orderPlaced](serializer, "OrderPlaced", kurrentdb.JsonCodec{}))
+	require.NoError(t, kurrentdb.RegisterEvent[*shared.StreamIdentifier](serializer, "StreamIdentified", kurrentdb.ProtobufCodec{}))
+
+	t.Run("RegisteringTwiceFails", func(t *testing.T) {
+		assert.Error(t, kurrentdb.RegisterEvent[orderPlaced](serializer, "OrderPlacedV2", kurrentdb.JsonCodec{}))
+		assert.Error(t, kurrentdb.RegisterEvent[string](serializer, "OrderPlaced", kurrentdb.JsonCodec{}))
+	})
+
+	t.Run("JsonRoundTrip", func(t *testing.T) {
+		event := orderPlaced{OrderId: "order-1", Total: 42.5}
+
+		data, err := serializer.Serialize(&event)
+		require.NoError(t, err)
+		assert.Equal(t, "OrderPlaced", data.EventType)
+		assert.Equal(t, kurrentdb.ContentTypeJson, data.ContentType)
+		assert.NotEqual(t, uuid.Nil, data.EventID)
+
+		decoded, err := serializer.Decode(&kurrentdb.ResolvedEvent{
+			Event: &kurrentdb.RecordedEvent{EventType: data.EventType, Data: data.Data},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, event, decoded)
+	})
+
+	t.Run("ResolvedLinkDecodesLinkedEvent", func(t *testing.T) {
+		event := orderPlaced{OrderId: "order-2", Total: 7}
+		data, err := serializer.Serialize(event)
+		require.NoError(t, err)
+
+		decoded, err := serializer.Decode(&kurrentdb.ResolvedEvent{
+			Link:  &kurrentdb.RecordedEvent{EventType: "$>", Data: []byte("0@order-2")},
+			Event: &kurrentdb.RecordedEvent{EventType: data.EventType, Data: data.Data},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, event, decoded)
+
+		// A link whose event was deleted is decoded as a link.
+		_, err = serializer.Decode(&kurrentdb.ResolvedEvent{
+			Link: &kurrentdb.RecordedEvent{EventType: "$>", Data: []byte("0@order-2")},
+		})
+		esErr, ok := kurrentdb.FromError(err)
+		assert.False(t, ok)
+		assert.Equal(t, kurrentdb.ErrorCodeUnknownEventType, esErr.Code())
+	})
+
+	t.Run("ProtobufRoundTrip", func(t *testing.T) {
+		event := &shared.StreamIdentifier{StreamName: []byte("orders")}
+
+		data, err := serializer.Serialize(event)
+		require.NoError(t, err)
+		assert.Equal(t, kurrentdb.ContentTypeBinary, data.ContentType)
+
+		decoded, err := serializer.Deserialize(&kurrentdb.RecordedEvent{EventType: data.EventType, Data: data.Data})
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(event, decoded.(*shared.StreamIdentifier)))
+	})
+
+	t.Run("UnknownTypesFail", func(t *testing.T) {
+		_, err := serializer.Serialize(struct{ Name string }{})
+		esErr, ok := kurrentdb.FromError(err)
+		assert.False(t, ok)
+		assert.Equal(t, kurrentdb.ErrorCodeUnknownEventType, esErr.Code())
+
+		_, err = serializer.Deserialize(&kurrentdb.RecordedEvent{EventType: "Unknown"})
+		esErr, ok = kurrentdb.FromError(err)
+		assert.False(t, ok)
+		assert.Equal(t, kurrentdb.ErrorCodeUnknownEventType, esErr.Code())
+	})
+
+	t.Run("NilCodecDefaultsToJson", func(t *testing.T) {
+		type orderShipped struct {
+			OrderId string `json:"orderId"`
+		}
+
+		require.NoError(t, kurrentdb.RegisterEvent[orderShipped](serializer, "OrderShipped", nil))
+
+		data, err := serializer.Serialize(orderShipped{OrderId: "order-3"})
+		require.NoError(t, err)
+		assert.Equal(t, kurrentdb.ContentTypeJson, data.ContentType)
+		assert.JSONEq(t, `{"orderId":"order-3"}`, string(data.Data))
+
+		decoded, err := serializer.Deserialize(&kurrentdb.RecordedEvent{EventType: data.EventType, Data: data.Data})
+		require.NoError(t, err)
+		assert.Equal(t, orderShipped{OrderId: "order-3"}, decoded)
+	})
+
+	t.Run("AppendEvents", func(t *testing.T) {
+		server, err := kurrentdbtest.NewServer()
+		require.NoError(t, err)
+		defer server.Close()
+
+		config, err := kurrentdb.ParseConnectionString(server.ConnectionString())
+		require.NoError(t, err)
+		config.Serializer = serializer
+
+		client, err := kurrentdb.NewClient(config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		streamID := uuid.NewString()
+		raw := fakeEvent("raw")
+		_, err = client.AppendEvents(context.Background(), streamID, kurrentdb.NoStream{}, orderPlaced{OrderId: "order-4", Total: 3}, raw)
+		require.NoError(t, err)
+
+		stream, err := client.ReadStream(context.Background(), streamID, kurrentdb.ReadStreamOptions{}, 10)
+		require.NoError(t, err)
+		events := readFakeStream(t, stream)
+		require.Len(t, events, 2)
+
+		decoded, err := serializer.Decode(events[0])
+		require.NoError(t, err)
+		assert.Equal(t, orderPlaced{OrderId: "order-4", Total: 3}, decoded)
+		assert.Equal(t, "raw", events[1].Event.EventType)
+		assert.Equal(t, raw.EventID, events[1].Event.EventID)
+
+		// Nothing is appended when a value's type is not registered.
+		_, err = client.AppendEvents(context.Background(), streamID, kurrentdb.Any{}, orderPlaced{OrderId: "order-4"}, struct{ Name string }{})
+		esErr, ok := kurrentdb.FromError(err)
+		assert.False(t, ok)
+		assert.Equal(t, kurrentdb.ErrorCodeUnknownEventType, esErr.Code())
+
+		stream, err = client.ReadStream(context.Background(), streamID, kurrentdb.ReadStreamOptions{}, 10)
+		require.NoError(t, err)
+		assert.Len(t, readFakeStream(t, stream), 2)
+	})
+}
@@ -2,6 +2,7 @@ package test
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"sync"
 	"testing"
@@ -305,3 +306,236 @@ func (s *SubscriptionTestSuite) TestSubscriptionToStreamCaughtUp() {
 	caughtUpTimedOut := fixture.WaitWithTimeout(&caughtUpReceived, testTimeout)
 	s.False(caughtUpTimedOut, "Timed out waiting for CaughtUp message")
 }
+
+func (s *SubscriptionTestSuite) TestStreamSubscriptionWithResubscribeDeliversEventsAndCloses() {
+	fixture := s.fixture
+	client := fixture.Client()
+
+	streamId := fixture.NewStreamId()
+	fixture.CreateTestEvents(streamId, 10)
+
+	subscription, err := client.SubscribeToStream(context.Background(), streamId, kurrentdb.SubscribeToStreamOptions{
+		From: kurrentdb.Start{},
+		Resubscribe: &kurrentdb.ResubscribeOptions{
+			MaxAttempts:    3,
+			InitialBackoff: 50 * time.Millisecond,
+		},
+	})
+	s.Require().NoError(err)
+
+	var dropped sync.WaitGroup
+	dropped.Add(1)
+
+	go func() {
+		defer dropped.Done()
+		count := 0
+
+		for {
+			subEvent := subscription.Recv()
+
+			if subEvent.EventAppeared != nil {
+				s.Equal(uint64(count), subEvent.EventAppeared.OriginalEvent().EventNumber)
+				count++
+
+				if count == 10 {
+					subscription.Close()
+				}
+
+				continue
+			}
+
+			s.Nil(subEvent.SubscriptionReconnecting, "closing a subscription must not trigger a resubscription")
+
+			if subEvent.SubscriptionDropped != nil {
+				s.Equal(10, count)
+				return
+			}
+		}
+	}()
+
+	timedOut := fixture.WaitWithTimeout(&dropped, time.Duration(10)*time.Second)
+	s.False(timedOut, "Timed out waiting for the subscription to be dropped")
+}
+
+func (s *SubscriptionTestSuite) TestStreamSubscriptionEventsIterator() {
+	fixture := s.fixture
+	client := fixture.Client()
+
+	streamId := fixture.NewStreamId()
+	testEvents := fixture.CreateTestEvents(streamId, 10)
+
+	subscription, err := client.SubscribeToStream(context.Background(), streamId, kurrentdb.SubscribeToStreamOptions{
+		From: kurrentdb.Start{},
+	})
+	s.Require().NoError(err)
+
+	var done sync.WaitGroup
+	done.Add(1)
+
+	go func() {
+		defer done.Done()
+		count := 0
+
+		for event, err := range subscription.Events() {
+			s.NoError(err)
+			s.Equal(testEvents[count].EventID, event.OriginalEvent().EventID)
+			count++
+
+			if count == len(testEvents) {
+				break
+			}
+		}
+	}()
+
+	timedOut := fixture.WaitWithTimeout(&done, time.Duration(10)*time.Second)
+	s.False(timedOut, "Timed out waiting for the iteration to complete")
+
+	subEvent := subscription.Recv()
+	s.NotNil(subEvent.SubscriptionDropped, "breaking out of the loop should close the subscription")
+}
+
+func (s *SubscriptionTestSuite) TestRunSubscriptionToAllPreservesPerStreamOrder() {
+	fixture := s.fixture
+	client := fixture.Client()
+
+	prefix := uuid.New().String() + "-"
+	streams := []string{prefix + "a", prefix + "b", prefix + "c"}
+	for _, streamId := range streams {
+		fixture.CreateTestEvents(streamId, 20)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	received := make(map[string][]uint64)
+	total := 0
+	var checkpoints []kurrentdb.Position
+
+	err := client.RunSubscriptionToAll(ctx, kurrentdb.SubscriptionRunnerOptions{
+		Subscription: kurrentdb.SubscribeToAllOptions{
+			From: kurrentdb.Start{},
+			Filter: &kurrentdb.SubscriptionFilter{
+				Type:     kurrentdb.StreamFilterType,
+				Prefixes: []string{prefix},
+			},
+		},
+		Workers: 3,
+		OnCheckpoint: func(position kurrentdb.Position) {
+			checkpoints = append(checkpoints, position)
+		},
+	}, func(ctx context.Context, event *kurrentdb.ResolvedEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		original := event.OriginalEvent()
+		received[original.StreamID] = append(received[original.StreamID], original.EventNumber)
+		total++
+
+		if total == 60 {
+			cancel()
+		}
+
+		return nil
+	})
+
+	s.NoError(err)
+	s.Equal(60, total)
+
+	for _, streamId := range streams {
+		s.Len(received[streamId], 20)
+		for i, revision := range received[streamId] {
+			s.Equal(uint64(i), revision, "events of a stream must be handled in order")
+		}
+	}
+
+	for i := 1; i < len(checkpoints); i++ {
+		s.True(checkpoints[i].Commit >= checkpoints[i-1].Commit, "the safe checkpoint must only move forward")
+	}
+}
+
+func (s *SubscriptionTestSuite) TestRunSubscriptionToAllStopsOnHandlerError() {
+	fixture := s.fixture
+	client := fixture.Client()
+
+	streamId := fixture.NewStreamId()
+	fixture.CreateTestEvents(streamId, 10)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	handlerErr := errors.New("handler failure")
+
+	err := client.RunSubscriptionToAll(ctx, kurrentdb.SubscriptionRunnerOptions{
+		Subscription: kurrentdb.SubscribeToAllOptions{
+			From: kurrentdb.Start{},
+			Filter: &kurrentdb.SubscriptionFilter{
+				Type:     kurrentdb.StreamFilterType,
+				Prefixes: []string{streamId},
+			},
+		},
+	}, func(ctx context.Context, event *kurrentdb.ResolvedEvent) error {
+		if event.OriginalEvent().EventNumber == 5 {
+			return handlerErr
+		}
+
+		return nil
+	})
+
+	s.ErrorIs(err, handlerErr)
+}
+
+func (s *SubscriptionTestSuite) TestStreamSubscriptionWithCheckpointResumesFromStoredRevision() {
+	fixture := s.fixture
+	client := fixture.Client()
+
+	streamId := fixture.NewStreamId()
+	fixture.CreateTestEvents(streamId, 10)
+	checkpointStreamId := fixture.NewStreamId()
+	store := kurrentdb.NewStreamCheckpointStore(client, checkpointStreamId)
+
+	subscribe := func() *kurrentdb.CheckpointedSubscription {
+		subscription, err := client.SubscribeToStreamWithCheckpoint(context.Background(), streamId, store, kurrentdb.SubscribeToStreamOptions{
+			From: kurrentdb.Start{},
+		}, kurrentdb.CheckpointOptions{
+			Interval: 5,
+		})
+		s.Require().NoError(err)
+		return subscription
+	}
+
+	next := func(subscription *kurrentdb.CheckpointedSubscription) *kurrentdb.ResolvedEvent {
+		for {
+			event := subscription.Recv()
+			s.Require().Nil(event.SubscriptionDropped)
+
+			if event.EventAppeared != nil {
+				return event.EventAppeared
+			}
+		}
+	}
+
+	subscription := subscribe()
+	for i := 0; i < 7; i++ {
+		s.Equal(uint64(i), next(subscription).OriginalEvent().EventNumber)
+	}
+	s.NoError(subscription.Close())
+
+	// Events up to revision 4 were handled when the fifth one was acknowledged.
+	subscription = subscribe()
+	defer subscription.Close()
+	s.Equal(uint64(5), next(subscription).OriginalEvent().EventNumber)
+
+	// A store that did not see the latest checkpoint must not overwrite it.
+	stale := kurrentdb.NewStreamCheckpointStore(client, checkpointStreamId)
+	_, err := stale.Load(context.Background())
+	s.Require().NoError(err)
+
+	revision := uint64(9)
+	s.Require().NoError(store.Store(context.Background(), kurrentdb.Checkpoint{Revision: &revision}))
+
+	err = stale.Store(context.Background(), kurrentdb.Checkpoint{Revision: &revision})
+	esErr, ok := kurrentdb.FromError(err)
+	s.False(ok)
+	s.Equal(kurrentdb.ErrorCodeWrongExpectedVersion, esErr.Code())
+}
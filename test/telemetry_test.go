@@ -0,0 +1,234 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdbtest"
+)
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+
+	return attribute.Value{}, false
+}
+
+// spanRecordingResolver fails every lookup, recording the span found in the context discovery runs under.
+type spanRecordingResolver struct {
+	mu    sync.Mutex
+	spans []trace.SpanContext
+}
+
+func (r *spanRecordingResolver) record(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, trace.SpanContextFromContext(ctx))
+	return errors.New("no such host")
+}
+
+func (r *spanRecordingResolver) LookupSRV(ctx context.Context, _, _, _ string) (string, []*net.SRV, error) {
+	return "", nil, r.record(ctx)
+}
+
+func (r *spanRecordingResolver) LookupHost(ctx context.Context, _ string) ([]string, error) {
+	return nil, r.record(ctx)
+}
+
+func TestTelemetry(t *testing.T) {
+	server, err := kurrentdbtest.NewServer()
+	require.NoError(t, err)
+	defer server.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	metrics := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(metrics))
+
+	config, err := kurrentdb.ParseConnectionString(server.ConnectionString())
+	require.NoError(t, err)
+	config.TracerProvider = tracerProvider
+	config.MeterProvider = meterProvider
+
+	client, err := kurrentdb.NewClient(config)
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "parent")
+	streamID := uuid.NewString()
+
+	_, err = client.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{StreamState: kurrentdb.NoStream{}}, fakeEvent("traced"))
+	require.NoError(t, err)
+
+	_, err = client.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{StreamState: kurrentdb.NoStream{}}, fakeEvent("conflict"))
+	require.Error(t, err)
+	parent.End()
+
+	t.Run("spansAroundOperations", func(t *testing.T) {
+		var appends []sdktrace.ReadOnlySpan
+		for _, span := range spans.Ended() {
+			if span.Name() == "streams.append" {
+				appends = append(appends, span)
+			}
+		}
+
+		require.Len(t, appends, 2)
+		assert.Equal(t, parent.SpanContext().TraceID(), appends[0].SpanContext().TraceID())
+		assert.Equal(t, trace.SpanKindClient, appends[0].SpanKind())
+
+		stream, ok := spanAttribute(appends[0], "db.kurrentdb.stream")
+		require.True(t, ok)
+		assert.Equal(t, streamID, stream.AsString())
+
+		state, ok := spanAttribute(appends[0], "db.kurrentdb.expected_state")
+		require.True(t, ok)
+		assert.Equal(t, "no_stream", state.AsString())
+
+		revision, ok := spanAttribute(appends[0], "db.kurrentdb.revision")
+		require.True(t, ok)
+		assert.Equal(t, int64(0), revision.AsInt64())
+
+		assert.Equal(t, codes.Error, appends[1].Status().Code)
+		code, ok := spanAttribute(appends[1], "db.kurrentdb.error_code")
+		require.True(t, ok)
+		assert.Equal(t, int64(kurrentdb.ErrorCodeWrongExpectedVersion), code.AsInt64())
+	})
+
+	t.Run("traceContextPropagatedToEvents", func(t *testing.T) {
+		stream, err := client.ReadStream(context.Background(), streamID, kurrentdb.ReadStreamOptions{}, 1)
+		require.NoError(t, err)
+		events := readFakeStream(t, stream)
+		require.Len(t, events, 1)
+
+		var metadata map[string]string
+		require.NoError(t, json.Unmarshal(events[0].Event.UserMetadata, &metadata))
+		assert.Equal(t, parent.SpanContext().TraceID().String(), metadata[kurrentdb.TraceIdMetadataKey])
+
+		extracted := trace.SpanContextFromContext(kurrentdb.ExtractTraceContext(context.Background(), events[0].Event))
+		assert.True(t, extracted.IsRemote())
+		assert.Equal(t, parent.SpanContext().TraceID(), extracted.TraceID())
+	})
+
+	t.Run("appendDurationMetric", func(t *testing.T) {
+		var data metricdata.ResourceMetrics
+		require.NoError(t, metrics.Collect(context.Background(), &data))
+
+		var histogram *metricdata.Histogram[float64]
+		for _, scope := range data.ScopeMetrics {
+			for _, m := range scope.Metrics {
+				if m.Name == "kurrentdb.client.append.duration" {
+					value := m.Data.(metricdata.Histogram[float64])
+					histogram = &value
+				}
+			}
+		}
+
+		require.NotNil(t, histogram)
+		count := uint64(0)
+		for _, point := range histogram.DataPoints {
+			count += point.Count
+		}
+
+		assert.Equal(t, uint64(2), count)
+	})
+
+	t.Run("userMetadataPreserved", func(t *testing.T) {
+		event := fakeEvent("preserved")
+		event.Metadata = []byte(`{"tenant":"acme","sequence":9007199254740993,"ratio":1.50}`)
+
+		preservedID := uuid.NewString()
+		_, err := client.AppendToStream(ctx, preservedID, kurrentdb.AppendToStreamOptions{}, event)
+		require.NoError(t, err)
+
+		stream, err := client.ReadStream(context.Background(), preservedID, kurrentdb.ReadStreamOptions{}, 1)
+		require.NoError(t, err)
+		events := readFakeStream(t, stream)
+		require.Len(t, events, 1)
+
+		var metadata map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(events[0].Event.UserMetadata, &metadata))
+		assert.Equal(t, `9007199254740993`, string(metadata["sequence"]))
+		assert.Equal(t, `1.50`, string(metadata["ratio"]))
+		assert.Equal(t, `"acme"`, string(metadata["tenant"]))
+		assert.Contains(t, metadata, kurrentdb.TraceIdMetadataKey)
+	})
+
+	t.Run("noInjectionWithoutTracerProvider", func(t *testing.T) {
+		untracedConfig, err := kurrentdb.ParseConnectionString(server.ConnectionString())
+		require.NoError(t, err)
+
+		untraced, err := kurrentdb.NewClient(untracedConfig)
+		require.NoError(t, err)
+		defer untraced.Close()
+
+		event := fakeEvent("untraced")
+		event.Metadata = []byte(`{ "sequence": 9007199254740993 }`)
+
+		untracedID := uuid.NewString()
+		spanCtx, span := tracerProvider.Tracer("test").Start(context.Background(), "application")
+		_, err = untraced.AppendToStream(spanCtx, untracedID, kurrentdb.AppendToStreamOptions{}, event)
+		span.End()
+		require.NoError(t, err)
+
+		stream, err := untraced.ReadStream(context.Background(), untracedID, kurrentdb.ReadStreamOptions{}, 1)
+		require.NoError(t, err)
+		events := readFakeStream(t, stream)
+		require.Len(t, events, 1)
+		assert.Equal(t, event.Metadata, events[0].Event.UserMetadata)
+	})
+
+	t.Run("projectionDiscoveryWithinSpan", func(t *testing.T) {
+		resolver := &spanRecordingResolver{}
+		projectionConfig, err := kurrentdb.ParseConnectionString(
+			"kurrentdb+discover://projections.cluster.test:2113?tls=false&maxDiscoverAttempts=1&circuitBreakerCooldown=0")
+		require.NoError(t, err)
+		projectionConfig.Logger = kurrentdb.NoopLogging()
+		projectionConfig.Resolver = resolver
+		projectionConfig.TracerProvider = tracerProvider
+
+		projections, err := kurrentdb.NewProjectionClient(projectionConfig)
+		require.NoError(t, err)
+		defer projections.Client().Close()
+
+		spanCtx, application := tracerProvider.Tracer("test").Start(context.Background(), "application")
+		err = projections.Create(spanCtx, "orders", "fromAll()", kurrentdb.CreateProjectionOptions{})
+		application.End()
+		require.Error(t, err)
+
+		var create sdktrace.ReadOnlySpan
+		for _, span := range spans.Ended() {
+			if span.Name() == "projections.create" {
+				create = span
+			}
+		}
+
+		require.NotNil(t, create)
+		assert.Equal(t, application.SpanContext().SpanID(), create.Parent().SpanID())
+
+		resolver.mu.Lock()
+		defer resolver.mu.Unlock()
+		require.NotEmpty(t, resolver.spans)
+		for _, span := range resolver.spans {
+			assert.Equal(t, create.SpanContext().SpanID(), span.SpanID())
+		}
+	})
+}
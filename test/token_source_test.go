@@ -0,0 +1,176 @@
+package test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdbtest"
+)
+
+// newTLSTestServer starts a fake server over TLS and returns it with a configuration trusting it.
+func newTLSTestServer(t *testing.T) (*kurrentdbtest.Server, *kurrentdb.Configuration) {
+	certPEM, keyPEM := selfSignedCertificatePEM(t, "server")
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	server, err := kurrentdbtest.NewTLSServer(&tls.Config{Certificates: []tls.Certificate{certificate}})
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	config, err := kurrentdb.ParseConnectionString(server.ConnectionString())
+	require.NoError(t, err)
+	config.Logger = kurrentdb.NoopLogging()
+	config.RootCAs = x509.NewCertPool()
+	require.True(t, config.RootCAs.AppendCertsFromPEM(certPEM))
+
+	return server, config
+}
+
+// nilTokenSource supplies no token and no error.
+type nilTokenSource struct{}
+
+func (nilTokenSource) Token(context.Context) (*kurrentdb.Token, error) {
+	return nil, nil
+}
+
+func TestTokenSources(t *testing.T) {
+	appendEvent := func(client *kurrentdb.Client, opts kurrentdb.AppendToStreamOptions) error {
+		_, err := client.AppendToStream(context.Background(), "tokens", opts, kurrentdb.EventData{
+			EventID:     uuid.New(),
+			EventType:   "Authenticated",
+			ContentType: kurrentdb.ContentTypeBinary,
+		})
+		return err
+	}
+
+	t.Run("staticToken", func(t *testing.T) {
+		server, config := newTLSTestServer(t)
+		config.Username = "admin"
+		config.Password = "changeit"
+		config.TokenSource = kurrentdb.StaticTokenSource("configured")
+
+		client, err := kurrentdb.NewClient(config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		require.NoError(t, appendEvent(client, kurrentdb.AppendToStreamOptions{}))
+		assert.Equal(t, "Bearer configured", server.Authorization())
+
+		require.NoError(t, appendEvent(client, kurrentdb.AppendToStreamOptions{
+			Authenticated: &kurrentdb.Credentials{Token: kurrentdb.StaticTokenSource("operation")},
+		}))
+		assert.Equal(t, "Bearer operation", server.Authorization())
+
+		require.NoError(t, appendEvent(client, kurrentdb.AppendToStreamOptions{
+			Authenticated: &kurrentdb.Credentials{Login: "ops", Password: "secret"},
+		}))
+		assert.Equal(t, "Basic b3BzOnNlY3JldA==", server.Authorization())
+	})
+
+	t.Run("missingToken", func(t *testing.T) {
+		server, config := newTLSTestServer(t)
+		config.TokenSource = nilTokenSource{}
+
+		client, err := kurrentdb.NewClient(config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		requireErrorCode(t, appendEvent(client, kurrentdb.AppendToStreamOptions{}), kurrentdb.ErrorCodeUnauthenticated)
+
+		err = appendEvent(client, kurrentdb.AppendToStreamOptions{
+			Authenticated: &kurrentdb.Credentials{Token: kurrentdb.StaticTokenSource("")},
+		})
+		requireErrorCode(t, err, kurrentdb.ErrorCodeUnauthenticated)
+		assert.Empty(t, server.Authorization())
+	})
+
+	t.Run("clientCredentials", func(t *testing.T) {
+		var requests atomic.Int32
+		authorizationServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := requests.Add(1)
+
+			clientID, clientSecret, ok := r.BasicAuth()
+			if !ok || clientID != "orders" || clientSecret != "s3cret" || r.FormValue("grant_type") != "client_credentials" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+				return
+			}
+
+			assert.Equal(t, "streams:read streams:write", r.FormValue("scope"))
+			assert.Equal(t, "kurrentdb", r.FormValue("audience"))
+
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"access_token": fmt.Sprintf("token-%d", n),
+				"token_type":   "bearer",
+				"expires_in":   1,
+			})
+		}))
+		defer authorizationServer.Close()
+
+		server, config := newTLSTestServer(t)
+		config.TokenSource = kurrentdb.NewClientCredentialsTokenSource(kurrentdb.ClientCredentials{
+			TokenURL:       authorizationServer.URL,
+			ClientID:       "orders",
+			ClientSecret:   "s3cret",
+			Scopes:         []string{"streams:read", "streams:write"},
+			EndpointParams: map[string][]string{"audience": {"kurrentdb"}},
+		})
+
+		client, err := kurrentdb.NewClient(config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		require.NoError(t, appendEvent(client, kurrentdb.AppendToStreamOptions{}))
+		require.NoError(t, appendEvent(client, kurrentdb.AppendToStreamOptions{}))
+		assert.Equal(t, "Bearer token-1", server.Authorization())
+		assert.Equal(t, int32(1), requests.Load())
+
+		// The token lives for a second and is refreshed halfway through.
+		time.Sleep(600 * time.Millisecond)
+
+		require.NoError(t, appendEvent(client, kurrentdb.AppendToStreamOptions{}))
+		assert.Equal(t, "Bearer token-2", server.Authorization())
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("clientCredentialsRejected", func(t *testing.T) {
+		authorizationServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client", "error_description": "unknown client"})
+		}))
+		defer authorizationServer.Close()
+
+		source := kurrentdb.NewClientCredentialsTokenSource(kurrentdb.ClientCredentials{
+			TokenURL: authorizationServer.URL,
+			ClientID: "unknown",
+		})
+
+		_, err := source.Token(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid_client unknown client")
+
+		_, config := newTLSTestServer(t)
+		config.TokenSource = source
+
+		client, err := kurrentdb.NewClient(config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		err = appendEvent(client, kurrentdb.AppendToStreamOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid_client")
+	})
+}
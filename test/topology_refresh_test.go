@@ -0,0 +1,103 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdb"
+	"github.com/kurrent-io/KurrentDB-Client-Go/kurrentdbtest"
+)
+
+func nextConnectedEvent(t *testing.T, events <-chan *kurrentdb.ConnectionEvent) *kurrentdb.ConnectionConnected {
+	for {
+		if event := nextConnectionEvent(t, events); event.Connected != nil {
+			return event.Connected
+		}
+	}
+}
+
+func TestTopologyRefresh(t *testing.T) {
+	first, err := kurrentdbtest.NewServer()
+	require.NoError(t, err)
+	defer first.Close()
+
+	second, err := kurrentdbtest.NewServer()
+	require.NoError(t, err)
+	defer second.Close()
+
+	setGossip := func(follower, leader *kurrentdbtest.Server) {
+		members := []kurrentdbtest.Member{
+			{Addr: follower.Addr(), State: kurrentdb.NodeStateFollower},
+			{Addr: leader.Addr(), State: kurrentdb.NodeStateLeader},
+		}
+
+		first.SetGossip(members...)
+		second.SetGossip(members...)
+	}
+
+	setGossip(first, second)
+
+	config, err := kurrentdb.ParseConnectionString(fmt.Sprintf(
+		"kurrentdb://%s,%s?tls=false&nodePreference=follower&gossipRefreshInterval=50", first.Addr(), second.Addr()))
+	require.NoError(t, err)
+	config.Logger = kurrentdb.NoopLogging()
+
+	client, err := kurrentdb.NewClient(config)
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx := context.Background()
+	events := client.WatchConnection(ctx)
+	streamID := uuid.NewString()
+
+	subscription, err := client.SubscribeToStream(ctx, streamID, kurrentdb.SubscribeToStreamOptions{From: kurrentdb.Start{}})
+	require.NoError(t, err)
+	defer subscription.Close()
+
+	connected := nextConnectedEvent(t, events)
+	assert.Equal(t, first.Addr(), connected.Endpoint.String())
+	assert.Equal(t, kurrentdb.NodeStateFollower, connected.NodeState)
+
+	setGossip(second, first)
+
+	connected = nextConnectedEvent(t, events)
+	assert.Equal(t, second.Addr(), connected.Endpoint.String())
+	assert.Equal(t, kurrentdb.NodeStateFollower, connected.NodeState)
+
+	t.Run("newOperationsUseTheBetterNode", func(t *testing.T) {
+		secondClient, err := second.NewClient()
+		require.NoError(t, err)
+		defer secondClient.Close()
+
+		_, err = secondClient.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{}, fakeEvent("migrated"))
+		require.NoError(t, err)
+
+		stream, err := client.ReadStream(ctx, streamID, kurrentdb.ReadStreamOptions{}, 10)
+		require.NoError(t, err)
+		require.Len(t, readFakeStream(t, stream), 1)
+	})
+
+	t.Run("inFlightSubscriptionsDrain", func(t *testing.T) {
+		firstClient, err := first.NewClient()
+		require.NoError(t, err)
+		defer firstClient.Close()
+
+		_, err = firstClient.AppendToStream(ctx, streamID, kurrentdb.AppendToStreamOptions{}, fakeEvent("drained"))
+		require.NoError(t, err)
+
+		for {
+			event := subscription.Recv()
+			require.Nil(t, event.SubscriptionDropped)
+
+			if event.EventAppeared != nil {
+				assert.Equal(t, "drained", event.EventAppeared.Event.EventType)
+				return
+			}
+		}
+	})
+}